@@ -0,0 +1,10 @@
+// Package assets is a data-only package: its Go file exists solely to
+// give internal/assets a go:embed anchor point for the styles, markdown,
+// and help files that live alongside it, since go:embed patterns can't
+// reach outside their own package directory.
+package assets
+
+import "embed"
+
+//go:embed styles markdown help
+var FS embed.FS
@@ -0,0 +1,48 @@
+// Package assets exposes the TUI's runtime assets (styles, glamour
+// markdown themes, help text) as a virtual filesystem embedded at build
+// time via go:embed, with a user override directory checked first so
+// custom themes don't require recompiling.
+package assets
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	rawassets "nvim-hoverfloat/cmd/context-tui/assets"
+)
+
+// FS is the embedded filesystem built from cmd/context-tui/assets.
+var FS http.FileSystem = http.FS(rawassets.FS)
+
+// userThemesDir returns ~/.config/nvim-hoverfloat/themes, where users can
+// drop JSON theme or glamour style overrides.
+func userThemesDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "nvim-hoverfloat", "themes")
+}
+
+// Open reads an asset by its path relative to the assets root (e.g.
+// "/styles/tokyonight.json"), preferring a user override of the same
+// basename under ~/.config/nvim-hoverfloat/themes/ before falling back to
+// the embedded default.
+func Open(name string) ([]byte, error) {
+	if dir := userThemesDir(); dir != "" {
+		override := filepath.Join(dir, filepath.Base(name))
+		if data, err := os.ReadFile(override); err == nil {
+			return data, nil
+		}
+	}
+
+	f, err := FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
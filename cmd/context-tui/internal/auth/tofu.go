@@ -0,0 +1,159 @@
+// Package auth implements trust-on-first-use (TOFU) authentication for the
+// TUI's Unix socket: the first client to connect has its ed25519 public
+// key fingerprint recorded, and later connections must prove possession of
+// the matching private key before any socket.Message frames are accepted.
+// This closes off the socket to any local process that happens to be able
+// to connect to /tmp/nvim_context.sock.
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// NonceSize is the length, in bytes, of the challenge nonce sent to a
+// connecting client.
+const NonceSize = 32
+
+// Challenge is sent by the TUI immediately after accept, before any
+// Message frames are processed.
+type Challenge struct {
+	Type  string `json:"type"`
+	Nonce string `json:"nonce"` // hex-encoded
+}
+
+// Response is the client's reply: its public key and a signature over the
+// challenge nonce proving ownership of the matching private key.
+type Response struct {
+	Type      string `json:"type"`
+	PublicKey string `json:"pubkey"`    // hex-encoded ed25519 public key
+	Signature string `json:"signature"` // hex-encoded signature over Nonce
+}
+
+// NewNonce generates a fresh random challenge nonce.
+func NewNonce() ([]byte, error) {
+	nonce := make([]byte, NonceSize)
+	_, err := rand.Read(nonce)
+	return nonce, err
+}
+
+// Fingerprint returns the hex-encoded SHA-256 digest of a public key, used
+// as its stable identity in the known_clients store.
+func Fingerprint(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyResponse checks that resp.Signature is a valid ed25519 signature
+// over nonce by the key in resp.PublicKey, returning the verified key and
+// its fingerprint.
+func VerifyResponse(nonce []byte, resp Response) (ed25519.PublicKey, string, error) {
+	pubBytes, err := hex.DecodeString(resp.PublicKey)
+	if err != nil || len(pubBytes) != ed25519.PublicKeySize {
+		return nil, "", fmt.Errorf("invalid public key")
+	}
+	sig, err := hex.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid signature encoding")
+	}
+
+	pub := ed25519.PublicKey(pubBytes)
+	if !ed25519.Verify(pub, nonce, sig) {
+		return nil, "", fmt.Errorf("signature verification failed")
+	}
+
+	return pub, Fingerprint(pub), nil
+}
+
+// KnownClients is the on-disk TOFU trust store: client label -> trusted
+// fingerprint. The TUI checks every connection, however many sessions are
+// attached at once, under one fixed label (see trustLabel in package
+// model), so in practice this holds one entry that all of them share.
+// A connection is authenticated from its own per-connection goroutine
+// (see App.authenticateAndServe) while acceptConnections keeps accepting
+// more in parallel, so every read and write of the underlying map is
+// guarded by mu the same way SessionRegistry guards its session map.
+type KnownClients struct {
+	mu      sync.Mutex
+	clients map[string]string
+}
+
+// NewKnownClients returns an empty trust store.
+func NewKnownClients() *KnownClients {
+	return &KnownClients{clients: make(map[string]string)}
+}
+
+// DefaultPath is where the trust store lives.
+func DefaultPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "nvim-hoverfloat", "known_clients")
+}
+
+// Load reads the trust store from path, returning an empty store if it
+// doesn't exist yet (first run).
+func Load(path string) (*KnownClients, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewKnownClients(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make(map[string]string)
+	if err := json.Unmarshal(data, &clients); err != nil {
+		return nil, err
+	}
+	return &KnownClients{clients: clients}, nil
+}
+
+// Save persists the trust store to path.
+func (kc *KnownClients) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	kc.mu.Lock()
+	data, err := json.MarshalIndent(kc.clients, "", "  ")
+	kc.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Check compares a connecting fingerprint against the trusted one recorded
+// for label. If no trust has been established yet (first use), it records
+// the fingerprint as trusted and returns ok=true, isNew=true. If a
+// fingerprint is already recorded and matches, ok=true, isNew=false. If it
+// differs, ok=false so the caller can surface a trust-violation prompt.
+func (kc *KnownClients) Check(label, fingerprint string) (ok bool, isNew bool) {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	trusted, exists := kc.clients[label]
+	if !exists {
+		kc.clients[label] = fingerprint
+		return true, true
+	}
+	return trusted == fingerprint, false
+}
+
+// Trust records fingerprint as the trusted key for label, overwriting any
+// previous value. Used when the user explicitly accepts a changed key
+// after a trust-violation prompt.
+func (kc *KnownClients) Trust(label, fingerprint string) {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+	kc.clients[label] = fingerprint
+}
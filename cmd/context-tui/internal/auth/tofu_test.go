@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+)
+
+func TestVerifyResponseAcceptsValidSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	nonce, err := NewNonce()
+	if err != nil {
+		t.Fatalf("NewNonce failed: %v", err)
+	}
+
+	resp := Response{
+		Type:      "auth_response",
+		PublicKey: hex.EncodeToString(pub),
+		Signature: hex.EncodeToString(ed25519.Sign(priv, nonce)),
+	}
+
+	gotPub, gotFingerprint, err := VerifyResponse(nonce, resp)
+	if err != nil {
+		t.Fatalf("VerifyResponse failed: %v", err)
+	}
+	if !gotPub.Equal(pub) {
+		t.Errorf("VerifyResponse returned a different public key than was signed with")
+	}
+	if gotFingerprint != Fingerprint(pub) {
+		t.Errorf("VerifyResponse fingerprint = %q, want %q", gotFingerprint, Fingerprint(pub))
+	}
+}
+
+func TestVerifyResponseRejectsWrongSignature(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	_, otherPriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	nonce, err := NewNonce()
+	if err != nil {
+		t.Fatalf("NewNonce failed: %v", err)
+	}
+
+	// Signed with a different key than the one presented as PublicKey, so
+	// verification must fail even though both the key and signature are
+	// individually well-formed.
+	resp := Response{
+		PublicKey: hex.EncodeToString(pub),
+		Signature: hex.EncodeToString(ed25519.Sign(otherPriv, nonce)),
+	}
+
+	if _, _, err := VerifyResponse(nonce, resp); err == nil {
+		t.Error("Expected VerifyResponse to reject a signature from a different key")
+	}
+}
+
+func TestVerifyResponseRejectsWrongSizeKey(t *testing.T) {
+	nonce, err := NewNonce()
+	if err != nil {
+		t.Fatalf("NewNonce failed: %v", err)
+	}
+
+	resp := Response{
+		PublicKey: hex.EncodeToString([]byte{0x01, 0x02, 0x03}),
+		Signature: hex.EncodeToString(make([]byte, ed25519.SignatureSize)),
+	}
+
+	if _, _, err := VerifyResponse(nonce, resp); err == nil {
+		t.Error("Expected VerifyResponse to reject a public key of the wrong size")
+	}
+}
+
+func TestVerifyResponseRejectsMalformedHex(t *testing.T) {
+	nonce, err := NewNonce()
+	if err != nil {
+		t.Fatalf("NewNonce failed: %v", err)
+	}
+
+	resp := Response{
+		PublicKey: "not-hex",
+		Signature: "not-hex",
+	}
+
+	if _, _, err := VerifyResponse(nonce, resp); err == nil {
+		t.Error("Expected VerifyResponse to reject a non-hex-encoded public key")
+	}
+}
+
+func TestKnownClientsCheckFirstUseTrustsOnSight(t *testing.T) {
+	kc := NewKnownClients()
+
+	ok, isNew := kc.Check("neovim", "abc123")
+	if !ok || !isNew {
+		t.Errorf("Check on an unknown label = (%v, %v), want (true, true)", ok, isNew)
+	}
+
+	ok, isNew = kc.Check("neovim", "abc123")
+	if !ok || isNew {
+		t.Errorf("Check on a now-trusted matching fingerprint = (%v, %v), want (true, false)", ok, isNew)
+	}
+}
+
+func TestKnownClientsCheckRejectsMismatch(t *testing.T) {
+	kc := NewKnownClients()
+
+	if ok, _ := kc.Check("neovim", "abc123"); !ok {
+		t.Fatal("first-use Check should trust the fingerprint")
+	}
+
+	ok, isNew := kc.Check("neovim", "different-fingerprint")
+	if ok || isNew {
+		t.Errorf("Check with a mismatched fingerprint = (%v, %v), want (false, false)", ok, isNew)
+	}
+}
+
+func TestKnownClientsTrustOverwritesMismatch(t *testing.T) {
+	kc := NewKnownClients()
+	kc.Check("neovim", "abc123")
+
+	kc.Trust("neovim", "new-fingerprint")
+
+	ok, isNew := kc.Check("neovim", "new-fingerprint")
+	if !ok || isNew {
+		t.Errorf("Check after Trust = (%v, %v), want (true, false)", ok, isNew)
+	}
+}
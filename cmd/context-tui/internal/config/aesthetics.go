@@ -2,20 +2,36 @@ package config
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 // AestheticsConfig holds all styling configuration
 type AestheticsConfig struct {
-	Colors     ColorConfig     `json:"colors"`
-	Formatting FormattingConfig `json:"formatting"`
-	Layout     LayoutConfig     `json:"layout"`
-	Markdown   MarkdownConfig   `json:"markdown"`
-	Debug      DebugConfig      `json:"debug"`
+	Colors     ColorConfig          `json:"colors"`
+	Formatting FormattingConfig     `json:"formatting"`
+	Layout     LayoutConfig         `json:"layout"`
+	Markdown   MarkdownConfig       `json:"markdown"`
+	Debug      DebugConfig          `json:"debug"`
+	Styles     map[string]StyleSpec `json:"styles"`
+
+	// StyleSetName, set by a top-level `styleset-name` key, names a
+	// .conf file to load (from the same directories as the main config)
+	// on top of whatever this file sets, so switching themes doesn't
+	// require editing it.
+	StyleSetName string `json:"styleset_name,omitempty"`
+
+	// BaseTheme, set by a top-level `base_theme` key, names one of the
+	// themes bundled via go:embed (see ListThemes) whose fields
+	// pre-populate this config before the rest of the file is applied,
+	// so a user only has to list the handful of colors they want to
+	// tweak. It's recorded here purely for introspection -- applying it
+	// happens immediately when the key is scanned, via applyBaseTheme.
+	BaseTheme string `json:"base_theme,omitempty"`
 }
 
 type ColorConfig struct {
@@ -124,48 +140,129 @@ type DebugConfig struct {
 	ValidateConsistency  bool `json:"validate_consistency"`
 }
 
-// Global configuration instance
-var Config *AestheticsConfig
+// Config is the active configuration, published via Store so concurrent
+// readers (the render pipeline, in particular) never observe a torn
+// struct while WatchAestheticsConfig is swapping in a reload.
+var Config atomic.Pointer[AestheticsConfig]
+
+// LoadAestheticsConfig loads the aesthetics configuration. configPath, if
+// non-empty (e.g. from an --aesthetics flag), is used directly after
+// tilde expansion; otherwise aesthetics.conf is searched for via
+// StylesetSearchDirs, falling back to the legacy project-relative and
+// executable-relative locations for compatibility with existing setups.
+//
+// If the resolved file sets a top-level `styleset-name` key, the named
+// .conf file is located the same way and layered on top: any key it sets
+// overwrites the main file's value, so a user switches themes by editing
+// just that one key.
+//
+// In strict mode every bad key/value in the file is collected into a
+// *ValidationErrors instead of the first one winning, and the fully
+// layered config is run through Validate() before it's returned -- so a
+// user iterating on a theme sees every mistake at once. Non-strict mode
+// keeps the old fail-on-first-error behavior, for callers (tests, a
+// quick --aesthetics sanity check) that just want the first problem.
+//
+// configPath may also be a "theme:<name>" pseudo-path (e.g.
+// "theme:gruvbox-dark"), in which case no file is touched at all -- the
+// named built-in theme is loaded straight from the embedded FS. This is
+// for previewing a bundled theme directly, as opposed to base_theme,
+// which layers one underneath a real config file on disk.
+func LoadAestheticsConfig(configPath string, strict bool) (*AestheticsConfig, error) {
+	if name, ok := strings.CutPrefix(configPath, themePathPrefix); ok {
+		config, err := loadEmbeddedThemeStrict(name, strict)
+		if err != nil {
+			return nil, err
+		}
+		if strict {
+			if verrs := config.Validate(); verrs.HasErrors() {
+				return nil, verrs
+			}
+		}
+		Config.Store(config)
+		return config, nil
+	}
 
-// LoadAestheticsConfig loads configuration from aesthetics.conf
-func LoadAestheticsConfig(configPath string) (*AestheticsConfig, error) {
-	if configPath == "" {
-		// Default path relative to project root
-		configPath = "config/aesthetics.conf"
+	resolved, tried, err := resolveConfigPath(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("aesthetics.conf not found, tried: %s", strings.Join(tried, ", "))
 	}
-	
-	// Try to find the config file
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		// Try relative to executable
-		execPath, _ := os.Executable()
-		execDir := filepath.Dir(execPath)
-		configPath = filepath.Join(execDir, "..", "..", "config", "aesthetics.conf")
-		
-		if _, err := os.Stat(configPath); os.IsNotExist(err) {
-			return nil, fmt.Errorf("aesthetics.conf not found")
+
+	config, err := loadFromResolvedPath(resolved, strict)
+	if err != nil {
+		return nil, err
+	}
+
+	if strict {
+		if verrs := config.Validate(); verrs.HasErrors() {
+			return nil, verrs
 		}
 	}
 
+	Config.Store(config)
+
+	return config, nil
+}
+
+// loadFromResolvedPath parses resolved (and, if it sets a styleset-name,
+// the referenced styleset file) the same way LoadAestheticsConfig does,
+// without repeating discovery -- shared by the initial load and every
+// reload WatchAestheticsConfig triggers.
+func loadFromResolvedPath(resolved string, strict bool) (*AestheticsConfig, error) {
 	config := &AestheticsConfig{}
-	
-	file, err := os.Open(configPath)
+	if err := scanAestheticsFile(config, resolved, strict); err != nil {
+		return nil, err
+	}
+
+	if config.StyleSetName != "" {
+		styleset, tried, err := resolveStyleset(config.StyleSetName)
+		if err != nil {
+			return nil, fmt.Errorf("styleset %q not found, tried: %s", config.StyleSetName, strings.Join(tried, ", "))
+		}
+		if err := scanAestheticsFile(config, styleset, strict); err != nil {
+			return nil, err
+		}
+	}
+
+	return config, nil
+}
+
+// scanAestheticsFile parses path as aesthetics.conf-format INI and
+// applies its keys onto config, so it can be called more than once (main
+// file, then a selected styleset) to layer settings: a later call's keys
+// overwrite the same field, but it never clears a field the earlier call
+// set and it doesn't mention. In strict mode every bad line is collected
+// into a *ValidationErrors rather than returning on the first one.
+func scanAestheticsFile(config *AestheticsConfig, path string, strict bool) error {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open config file: %v", err)
+		return fmt.Errorf("failed to open config file: %v", err)
 	}
-	defer file.Close()
+	return scanAestheticsBytes(config, data, path, strict)
+}
 
-	scanner := bufio.NewScanner(file)
+// scanAestheticsBytes is scanAestheticsFile's parsing core, taking the
+// file's contents directly so a built-in theme embedded via go:embed can
+// be scanned the same way as one read from disk. label stands in for the
+// path in error messages (a real path on disk, or "theme:<name>" for an
+// embedded one).
+func scanAestheticsBytes(config *AestheticsConfig, data []byte, label string, strict bool) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
 	currentSection := ""
 	currentSubsection := ""
+	lineNo := 0
+	var errs ValidationErrors
 
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		
+		lineNo++
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+
 		// Skip empty lines and comments
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
-		
+
 		// Section headers
 		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
 			section := strings.Trim(line, "[]")
@@ -178,31 +275,105 @@ func LoadAestheticsConfig(configPath string) (*AestheticsConfig, error) {
 			}
 			continue
 		}
-		
+
 		// Key-value pairs
 		if strings.Contains(line, "=") {
 			parts := strings.SplitN(line, "=", 2)
 			key := strings.TrimSpace(parts[0])
 			value := strings.TrimSpace(parts[1])
-			
+
 			// Remove quotes if present
 			value = strings.Trim(value, "\"")
-			
-			err := setConfigValue(config, currentSection, currentSubsection, key, value)
-			if err != nil {
-				return nil, fmt.Errorf("error setting config value %s.%s.%s: %v", currentSection, currentSubsection, key, err)
+
+			// Column of the value, for error messages: where it actually
+			// starts in the raw (un-trimmed) line, past the "=" and any
+			// whitespace before the value.
+			col := valueColumn(raw)
+
+			if currentSection == "" {
+				if err := setTopLevelValue(config, key, value); err != nil {
+					lineErr := fmt.Errorf("%s:%d:%d: error setting %s: %v", label, lineNo, col, key, err)
+					if !strict {
+						return lineErr
+					}
+					errs.addError(lineErr)
+				}
+				continue
+			}
+
+			if err := setConfigValue(config, currentSection, currentSubsection, key, value); err != nil {
+				lineErr := fmt.Errorf("%s:%d:%d: error setting config value %s.%s.%s: %v", label, lineNo, col, currentSection, currentSubsection, key, err)
+				if !strict {
+					return lineErr
+				}
+				errs.addError(lineErr)
 			}
 		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading config file: %v", err)
+		return fmt.Errorf("error reading config file: %v", err)
 	}
 
-	// Set global config
-	Config = config
-	
-	return config, nil
+	if strict && errs.HasErrors() {
+		return &errs
+	}
+
+	return nil
+}
+
+// valueColumn returns the 1-based column of the value on raw, a
+// key = value line: past the "=" and any whitespace before the value
+// itself, so an error points at what's actually wrong rather than the
+// separator.
+func valueColumn(raw string) int {
+	eq := strings.Index(raw, "=")
+	if eq < 0 {
+		return 1
+	}
+	rest := raw[eq+1:]
+	return eq + 1 + (len(rest) - len(strings.TrimLeft(rest, " \t"))) + 1
+}
+
+// setTopLevelValue handles keys that appear before any [section] header.
+func setTopLevelValue(config *AestheticsConfig, key, value string) error {
+	switch key {
+	case "styleset-name":
+		config.StyleSetName = value
+		return nil
+	case "base_theme":
+		return applyBaseTheme(config, value)
+	default:
+		return fmt.Errorf("unknown top-level key: %s", key)
+	}
+}
+
+// applyBaseTheme pre-populates config's colors, formatting, layout,
+// markdown, debug, and named styles from the bundled theme named name,
+// so every key the rest of the file goes on to set simply overwrites
+// that one field. It's meant to be the first key in a user config --
+// scanAestheticsBytes applies keys in file order, so anything that
+// follows it naturally wins over the theme's default.
+func applyBaseTheme(config *AestheticsConfig, name string) error {
+	base, err := loadEmbeddedTheme(name)
+	if err != nil {
+		return err
+	}
+
+	config.Colors = base.Colors
+	config.Formatting = base.Formatting
+	config.Layout = base.Layout
+	config.Markdown = base.Markdown
+	config.Debug = base.Debug
+	if len(base.Styles) > 0 {
+		config.Styles = make(map[string]StyleSpec, len(base.Styles))
+		for k, spec := range base.Styles {
+			config.Styles[k] = spec
+		}
+	}
+	config.BaseTheme = name
+
+	return nil
 }
 
 // setConfigValue sets a configuration value based on section, subsection, and key
@@ -218,138 +389,207 @@ func setConfigValue(config *AestheticsConfig, section, subsection, key, value st
 		return setMarkdownValue(config, key, value)
 	case "debug":
 		return setDebugValue(config, key, value)
+	case "styles":
+		return setStylesValue(config, subsection, key, value)
 	default:
 		return fmt.Errorf("unknown section: %s", section)
 	}
 }
 
 func setColorValue(config *AestheticsConfig, subsection, key, value string) error {
+	target, err := colorFieldTarget(config, subsection, key)
+	if err != nil {
+		return err
+	}
+
+	// Validate the spec's syntax now, against a placeholder palette
+	// rather than config.Colors.Accent: an accent.<name> reference may
+	// point at an [colors.accent] section that hasn't been scanned yet,
+	// since sections can appear in any order, so real resolution happens
+	// lazily (at render time, against the fully-loaded config) instead.
+	if _, err := ParseColor(value, placeholderAccents); err != nil {
+		return fmt.Errorf("invalid color %q: %w", value, err)
+	}
+
+	*target = value
+	return nil
+}
+
+// colorFieldTarget returns a pointer to the ColorConfig field named by
+// subsection and key, so setColorValue can validate a spec once before
+// assigning it, instead of duplicating validation across every case.
+func colorFieldTarget(config *AestheticsConfig, subsection, key string) (*string, error) {
 	switch subsection {
 	case "background":
 		switch key {
 		case "primary":
-			config.Colors.Background.Primary = value
+			return &config.Colors.Background.Primary, nil
 		case "secondary":
-			config.Colors.Background.Secondary = value
+			return &config.Colors.Background.Secondary, nil
 		case "accent":
-			config.Colors.Background.Accent = value
+			return &config.Colors.Background.Accent, nil
 		case "floating":
-			config.Colors.Background.Floating = value
+			return &config.Colors.Background.Floating, nil
 		case "code_block":
-			config.Colors.Background.CodeBlock = value
+			return &config.Colors.Background.CodeBlock, nil
 		case "selection":
-			config.Colors.Background.Selection = value
+			return &config.Colors.Background.Selection, nil
 		default:
-			return fmt.Errorf("unknown background color: %s", key)
+			return nil, fmt.Errorf("unknown background color: %s", key)
 		}
 	case "foreground":
 		switch key {
 		case "primary":
-			config.Colors.Foreground.Primary = value
+			return &config.Colors.Foreground.Primary, nil
 		case "secondary":
-			config.Colors.Foreground.Secondary = value
+			return &config.Colors.Foreground.Secondary, nil
 		case "comment":
-			config.Colors.Foreground.Comment = value
+			return &config.Colors.Foreground.Comment, nil
 		case "dark":
-			config.Colors.Foreground.Dark = value
+			return &config.Colors.Foreground.Dark, nil
 		case "inverse":
-			config.Colors.Foreground.Inverse = value
+			return &config.Colors.Foreground.Inverse, nil
 		default:
-			return fmt.Errorf("unknown foreground color: %s", key)
+			return nil, fmt.Errorf("unknown foreground color: %s", key)
 		}
 	case "accent":
 		switch key {
 		case "blue":
-			config.Colors.Accent.Blue = value
+			return &config.Colors.Accent.Blue, nil
 		case "green":
-			config.Colors.Accent.Green = value
+			return &config.Colors.Accent.Green, nil
 		case "yellow":
-			config.Colors.Accent.Yellow = value
+			return &config.Colors.Accent.Yellow, nil
 		case "purple":
-			config.Colors.Accent.Purple = value
+			return &config.Colors.Accent.Purple, nil
 		case "red":
-			config.Colors.Accent.Red = value
+			return &config.Colors.Accent.Red, nil
 		case "orange":
-			config.Colors.Accent.Orange = value
+			return &config.Colors.Accent.Orange, nil
 		case "cyan":
-			config.Colors.Accent.Cyan = value
+			return &config.Colors.Accent.Cyan, nil
 		case "pink":
-			config.Colors.Accent.Pink = value
+			return &config.Colors.Accent.Pink, nil
 		default:
-			return fmt.Errorf("unknown accent color: %s", key)
+			return nil, fmt.Errorf("unknown accent color: %s", key)
 		}
 	case "semantic":
 		switch key {
 		case "border":
-			config.Colors.Semantic.Border = value
+			return &config.Colors.Semantic.Border, nil
 		case "border_dim":
-			config.Colors.Semantic.BorderDim = value
+			return &config.Colors.Semantic.BorderDim, nil
 		case "focus":
-			config.Colors.Semantic.Focus = value
+			return &config.Colors.Semantic.Focus, nil
 		case "error":
-			config.Colors.Semantic.Error = value
+			return &config.Colors.Semantic.Error, nil
 		case "warning":
-			config.Colors.Semantic.Warning = value
+			return &config.Colors.Semantic.Warning, nil
 		case "success":
-			config.Colors.Semantic.Success = value
+			return &config.Colors.Semantic.Success, nil
 		case "info":
-			config.Colors.Semantic.Info = value
+			return &config.Colors.Semantic.Info, nil
 		default:
-			return fmt.Errorf("unknown semantic color: %s", key)
+			return nil, fmt.Errorf("unknown semantic color: %s", key)
 		}
 	default:
-		return fmt.Errorf("unknown color subsection: %s", subsection)
+		return nil, fmt.Errorf("unknown color subsection: %s", subsection)
 	}
-	return nil
+}
+
+// parseBoolField parses value as a bool for the named key, wrapping
+// strconv's error so it reports which key the bad value came from
+// instead of being silently dropped and defaulting to false.
+func parseBoolField(key, value string) (bool, error) {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid bool value %q for %s: %w", value, key, err)
+	}
+	return b, nil
+}
+
+// parseIntField parses value as an int for the named key, wrapping
+// strconv's error the same way parseBoolField does.
+func parseIntField(key, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer value %q for %s: %w", value, key, err)
+	}
+	return n, nil
 }
 
 func setFormattingValue(config *AestheticsConfig, subsection, key, value string) error {
-	boolVal, _ := strconv.ParseBool(value)
-	intVal, _ := strconv.Atoi(value)
-	
 	switch subsection {
 	case "text":
+		switch key {
+		case "bold_headers", "italic_comments", "underline_links", "italic_emphasis":
+		default:
+			return fmt.Errorf("unknown text formatting: %s", key)
+		}
+		b, err := parseBoolField(key, value)
+		if err != nil {
+			return err
+		}
 		switch key {
 		case "bold_headers":
-			config.Formatting.Text.BoldHeaders = boolVal
+			config.Formatting.Text.BoldHeaders = b
 		case "italic_comments":
-			config.Formatting.Text.ItalicComments = boolVal
+			config.Formatting.Text.ItalicComments = b
 		case "underline_links":
-			config.Formatting.Text.UnderlineLinks = boolVal
+			config.Formatting.Text.UnderlineLinks = b
 		case "italic_emphasis":
-			config.Formatting.Text.ItalicEmphasis = boolVal
-		default:
-			return fmt.Errorf("unknown text formatting: %s", key)
+			config.Formatting.Text.ItalicEmphasis = b
 		}
 	case "sections":
 		switch key {
-		case "consistent_backgrounds":
-			config.Formatting.Sections.ConsistentBackgrounds = boolVal
-		case "full_width_backgrounds":
-			config.Formatting.Sections.FullWidthBackgrounds = boolVal
-		case "uniform_padding":
-			config.Formatting.Sections.UniformPadding = boolVal
+		case "consistent_backgrounds", "full_width_backgrounds", "uniform_padding":
+			b, err := parseBoolField(key, value)
+			if err != nil {
+				return err
+			}
+			switch key {
+			case "consistent_backgrounds":
+				config.Formatting.Sections.ConsistentBackgrounds = b
+			case "full_width_backgrounds":
+				config.Formatting.Sections.FullWidthBackgrounds = b
+			case "uniform_padding":
+				config.Formatting.Sections.UniformPadding = b
+			}
 		case "border_style":
 			config.Formatting.Sections.BorderStyle = value
-		case "padding_horizontal":
-			config.Formatting.Sections.PaddingHorizontal = intVal
-		case "padding_vertical":
-			config.Formatting.Sections.PaddingVertical = intVal
+		case "padding_horizontal", "padding_vertical":
+			n, err := parseIntField(key, value)
+			if err != nil {
+				return err
+			}
+			switch key {
+			case "padding_horizontal":
+				config.Formatting.Sections.PaddingHorizontal = n
+			case "padding_vertical":
+				config.Formatting.Sections.PaddingVertical = n
+			}
 		default:
 			return fmt.Errorf("unknown section formatting: %s", key)
 		}
 	case "code":
+		switch key {
+		case "highlight_syntax", "preserve_indentation", "background_consistent", "border_code_blocks":
+		default:
+			return fmt.Errorf("unknown code formatting: %s", key)
+		}
+		b, err := parseBoolField(key, value)
+		if err != nil {
+			return err
+		}
 		switch key {
 		case "highlight_syntax":
-			config.Formatting.Code.HighlightSyntax = boolVal
+			config.Formatting.Code.HighlightSyntax = b
 		case "preserve_indentation":
-			config.Formatting.Code.PreserveIndentation = boolVal
+			config.Formatting.Code.PreserveIndentation = b
 		case "background_consistent":
-			config.Formatting.Code.BackgroundConsistent = boolVal
+			config.Formatting.Code.BackgroundConsistent = b
 		case "border_code_blocks":
-			config.Formatting.Code.BorderCodeBlocks = boolVal
-		default:
-			return fmt.Errorf("unknown code formatting: %s", key)
+			config.Formatting.Code.BorderCodeBlocks = b
 		}
 	default:
 		return fmt.Errorf("unknown formatting subsection: %s", subsection)
@@ -358,30 +598,36 @@ func setFormattingValue(config *AestheticsConfig, subsection, key, value string)
 }
 
 func setLayoutValue(config *AestheticsConfig, subsection, key, value string) error {
-	intVal, _ := strconv.Atoi(value)
-	
 	switch subsection {
 	case "spacing":
+		n, err := parseIntField(key, value)
+		if err != nil {
+			return err
+		}
 		switch key {
 		case "section_margin_bottom":
-			config.Layout.Spacing.SectionMarginBottom = intVal
+			config.Layout.Spacing.SectionMarginBottom = n
 		case "header_padding":
-			config.Layout.Spacing.HeaderPadding = intVal
+			config.Layout.Spacing.HeaderPadding = n
 		case "footer_padding":
-			config.Layout.Spacing.FooterPadding = intVal
+			config.Layout.Spacing.FooterPadding = n
 		case "content_padding":
-			config.Layout.Spacing.ContentPadding = intVal
+			config.Layout.Spacing.ContentPadding = n
 		default:
 			return fmt.Errorf("unknown spacing config: %s", key)
 		}
 	case "dimensions":
+		n, err := parseIntField(key, value)
+		if err != nil {
+			return err
+		}
 		switch key {
 		case "min_width":
-			config.Layout.Dimensions.MinWidth = intVal
+			config.Layout.Dimensions.MinWidth = n
 		case "max_width":
-			config.Layout.Dimensions.MaxWidth = intVal
+			config.Layout.Dimensions.MaxWidth = n
 		case "default_height":
-			config.Layout.Dimensions.DefaultHeight = intVal
+			config.Layout.Dimensions.DefaultHeight = n
 		default:
 			return fmt.Errorf("unknown dimensions config: %s", key)
 		}
@@ -392,43 +638,58 @@ func setLayoutValue(config *AestheticsConfig, subsection, key, value string) err
 }
 
 func setMarkdownValue(config *AestheticsConfig, key, value string) error {
-	boolVal, _ := strconv.ParseBool(value)
-	
 	switch key {
-	case "use_glamour":
-		config.Markdown.UseGlamour = boolVal
 	case "theme":
 		config.Markdown.Theme = value
+		return nil
+	case "use_glamour", "code_highlighting", "preserve_formatting", "word_wrap":
+	default:
+		return fmt.Errorf("unknown markdown config: %s", key)
+	}
+
+	b, err := parseBoolField(key, value)
+	if err != nil {
+		return err
+	}
+	switch key {
+	case "use_glamour":
+		config.Markdown.UseGlamour = b
 	case "code_highlighting":
-		config.Markdown.CodeHighlighting = boolVal
+		config.Markdown.CodeHighlighting = b
 	case "preserve_formatting":
-		config.Markdown.PreserveFormatting = boolVal
+		config.Markdown.PreserveFormatting = b
 	case "word_wrap":
-		config.Markdown.WordWrap = boolVal
-	default:
-		return fmt.Errorf("unknown markdown config: %s", key)
+		config.Markdown.WordWrap = b
 	}
 	return nil
 }
 
 func setDebugValue(config *AestheticsConfig, key, value string) error {
-	boolVal, _ := strconv.ParseBool(value)
-	
+	switch key {
+	case "show_boundaries", "log_color_usage", "validate_consistency":
+	default:
+		return fmt.Errorf("unknown debug config: %s", key)
+	}
+
+	b, err := parseBoolField(key, value)
+	if err != nil {
+		return err
+	}
 	switch key {
 	case "show_boundaries":
-		config.Debug.ShowBoundaries = boolVal
+		config.Debug.ShowBoundaries = b
 	case "log_color_usage":
-		config.Debug.LogColorUsage = boolVal
+		config.Debug.LogColorUsage = b
 	case "validate_consistency":
-		config.Debug.ValidateConsistency = boolVal
-	default:
-		return fmt.Errorf("unknown debug config: %s", key)
+		config.Debug.ValidateConsistency = b
 	}
 	return nil
 }
 
-// InitializeAesthetics loads the aesthetics configuration
+// InitializeAesthetics loads the aesthetics configuration in strict
+// mode, so a broken theme is reported in full at startup rather than one
+// error at a time across repeated restarts.
 func InitializeAesthetics() error {
-	_, err := LoadAestheticsConfig("")
+	_, err := LoadAestheticsConfig("", true)
 	return err
 }
\ No newline at end of file
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// placeholderAccents stands in for a not-yet-loaded [colors.accent]
+// section when validating a spec's syntax during scanning: every known
+// accent name resolves to something, so a valid accent.<name> reference
+// passes regardless of section order, while a typo'd name still fails.
+var placeholderAccents = AccentColors{
+	Blue: "#000000", Green: "#000000", Yellow: "#000000", Purple: "#000000",
+	Red: "#000000", Orange: "#000000", Cyan: "#000000", Pink: "#000000",
+}
+
+// Color is a color value parsed from a config string: 24-bit RGB plus
+// the original spec it came from, so it can be re-rendered or quoted in
+// an error message exactly as the user wrote it.
+type Color struct {
+	R, G, B uint8
+	Spec    string
+}
+
+// Hex returns c's #rrggbb form.
+func (c Color) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// TermColor resolves c against profile, downgrading a truecolor spec to
+// the nearest 256-color or 16-color equivalent (or turning it off
+// entirely) on a terminal that can't render it directly -- so a theme
+// written entirely in hex still renders something reasonable over a
+// plain $TERM.
+func (c Color) TermColor(profile termenv.Profile) termenv.Color {
+	return profile.Color(c.Hex())
+}
+
+// ParseColor parses spec as one of:
+//   - "#rrggbb" or "#rgb"
+//   - "rgb(r,g,b)" with each component 0-255
+//   - a bare ANSI 256-palette index, 0-255
+//   - a named palette reference "accent.<name>", resolved against accents
+func ParseColor(spec string, accents AccentColors) (Color, error) {
+	s := strings.TrimSpace(spec)
+
+	switch {
+	case strings.HasPrefix(s, "#"):
+		return parseHexColor(s)
+	case strings.HasPrefix(s, "rgb(") && strings.HasSuffix(s, ")"):
+		return parseRGBFuncColor(s)
+	case strings.HasPrefix(s, "accent."):
+		return parseAccentColor(s, accents)
+	default:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return Color{}, fmt.Errorf("unrecognized color spec %q (want #rrggbb, #rgb, rgb(r,g,b), an ANSI index 0-255, or accent.<name>)", spec)
+		}
+		return parseANSIColor(n, s)
+	}
+}
+
+func parseHexColor(s string) (Color, error) {
+	hex := strings.TrimPrefix(s, "#")
+
+	switch len(hex) {
+	case 3:
+		r, err := hexDigit(hex[0:1])
+		if err != nil {
+			return Color{}, fmt.Errorf("invalid hex color %q: %w", s, err)
+		}
+		g, err := hexDigit(hex[1:2])
+		if err != nil {
+			return Color{}, fmt.Errorf("invalid hex color %q: %w", s, err)
+		}
+		b, err := hexDigit(hex[2:3])
+		if err != nil {
+			return Color{}, fmt.Errorf("invalid hex color %q: %w", s, err)
+		}
+		return Color{R: r * 17, G: g * 17, B: b * 17, Spec: s}, nil
+	case 6:
+		r, err := hexDigit(hex[0:2])
+		if err != nil {
+			return Color{}, fmt.Errorf("invalid hex color %q: %w", s, err)
+		}
+		g, err := hexDigit(hex[2:4])
+		if err != nil {
+			return Color{}, fmt.Errorf("invalid hex color %q: %w", s, err)
+		}
+		b, err := hexDigit(hex[4:6])
+		if err != nil {
+			return Color{}, fmt.Errorf("invalid hex color %q: %w", s, err)
+		}
+		return Color{R: r, G: g, B: b, Spec: s}, nil
+	default:
+		return Color{}, fmt.Errorf("invalid hex color %q: want #rgb or #rrggbb", s)
+	}
+}
+
+func hexDigit(s string) (uint8, error) {
+	v, err := strconv.ParseUint(s, 16, 8)
+	if err != nil {
+		return 0, err
+	}
+	return uint8(v), nil
+}
+
+func parseRGBFuncColor(s string) (Color, error) {
+	inner := strings.TrimSuffix(strings.TrimPrefix(s, "rgb("), ")")
+	parts := strings.Split(inner, ",")
+	if len(parts) != 3 {
+		return Color{}, fmt.Errorf("invalid rgb() color %q: want rgb(r,g,b)", s)
+	}
+
+	var components [3]uint8
+	for i, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 0 || n > 255 {
+			return Color{}, fmt.Errorf("invalid rgb() color %q: component %q is not 0-255", s, part)
+		}
+		components[i] = uint8(n)
+	}
+	return Color{R: components[0], G: components[1], B: components[2], Spec: s}, nil
+}
+
+func parseAccentColor(s string, accents AccentColors) (Color, error) {
+	name := strings.TrimPrefix(s, "accent.")
+
+	var resolved string
+	switch name {
+	case "blue":
+		resolved = accents.Blue
+	case "green":
+		resolved = accents.Green
+	case "yellow":
+		resolved = accents.Yellow
+	case "purple":
+		resolved = accents.Purple
+	case "red":
+		resolved = accents.Red
+	case "orange":
+		resolved = accents.Orange
+	case "cyan":
+		resolved = accents.Cyan
+	case "pink":
+		resolved = accents.Pink
+	default:
+		return Color{}, fmt.Errorf("unknown accent color reference %q", s)
+	}
+	if resolved == "" {
+		return Color{}, fmt.Errorf("accent color reference %q is not set", s)
+	}
+	if strings.HasPrefix(strings.TrimSpace(resolved), "accent.") {
+		return Color{}, fmt.Errorf("accent color reference %q points at another accent reference (%q); accent colors must resolve to a literal color", s, resolved)
+	}
+
+	c, err := ParseColor(resolved, accents)
+	if err != nil {
+		return Color{}, fmt.Errorf("accent color reference %q: %w", s, err)
+	}
+	c.Spec = s
+	return c, nil
+}
+
+func parseANSIColor(n int, spec string) (Color, error) {
+	if n < 0 || n > 255 {
+		return Color{}, fmt.Errorf("ANSI color index %d out of range 0-255", n)
+	}
+	r, g, b := ansi256ToRGB(n)
+	return Color{R: r, G: g, B: b, Spec: spec}, nil
+}
+
+// ansiBasicColors is the standard xterm palette for indices 0-15.
+var ansiBasicColors = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// ansi256ToRGB converts a standard xterm 256-color palette index to RGB:
+// 0-15 the basic ANSI colors above, 16-231 a 6x6x6 color cube, and
+// 232-255 a 24-step grayscale ramp.
+func ansi256ToRGB(n int) (uint8, uint8, uint8) {
+	if n < 16 {
+		c := ansiBasicColors[n]
+		return c[0], c[1], c[2]
+	}
+	if n < 232 {
+		n -= 16
+		return cubeLevel(n / 36), cubeLevel((n / 6) % 6), cubeLevel(n % 6)
+	}
+	gray := uint8(8 + (n-232)*10)
+	return gray, gray, gray
+}
+
+func cubeLevel(level int) uint8 {
+	if level == 0 {
+		return 0
+	}
+	return uint8(55 + level*40)
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseColorFormats(t *testing.T) {
+	cases := []struct {
+		spec    string
+		r, g, b uint8
+	}{
+		{"#fabd2f", 0xfa, 0xbd, 0x2f},
+		{"#fff", 0xff, 0xff, 0xff},
+		{"rgb(250, 189, 47)", 250, 189, 47},
+		{"0", 0, 0, 0},
+		{"196", 255, 0, 0},
+		{"232", 8, 8, 8},
+	}
+
+	for _, c := range cases {
+		got, err := ParseColor(c.spec, AccentColors{})
+		if err != nil {
+			t.Fatalf("ParseColor(%q) failed: %v", c.spec, err)
+		}
+		if got.R != c.r || got.G != c.g || got.B != c.b {
+			t.Errorf("ParseColor(%q) = {%d %d %d}, want {%d %d %d}", c.spec, got.R, got.G, got.B, c.r, c.g, c.b)
+		}
+		if got.Spec != c.spec {
+			t.Errorf("ParseColor(%q).Spec = %q, want %q", c.spec, got.Spec, c.spec)
+		}
+	}
+}
+
+func TestParseColorAccentReference(t *testing.T) {
+	accents := AccentColors{Blue: "#83a598"}
+
+	got, err := ParseColor("accent.blue", accents)
+	if err != nil {
+		t.Fatalf("ParseColor failed: %v", err)
+	}
+	if got.Hex() != "#83a598" {
+		t.Errorf("ParseColor(\"accent.blue\").Hex() = %q, want #83a598", got.Hex())
+	}
+	if got.Spec != "accent.blue" {
+		t.Errorf("ParseColor(\"accent.blue\").Spec = %q, want the reference, not the resolved value", got.Spec)
+	}
+}
+
+func TestParseColorAccentReferenceUnset(t *testing.T) {
+	if _, err := ParseColor("accent.blue", AccentColors{}); err == nil {
+		t.Error("ParseColor succeeded on an unset accent reference, want error")
+	}
+}
+
+func TestParseColorAccentReferenceCycleIsRejected(t *testing.T) {
+	accents := AccentColors{Blue: "accent.green", Green: "accent.blue"}
+
+	if _, err := ParseColor("accent.blue", accents); err == nil {
+		t.Error("ParseColor succeeded on a cyclic accent reference, want error")
+	}
+}
+
+func TestParseColorRejectsUnknownSpec(t *testing.T) {
+	cases := []string{"#ff", "not-a-color", "rgb(1,2)", "rgb(1,2,999)", "300", "accent.magenta"}
+	for _, spec := range cases {
+		if _, err := ParseColor(spec, AccentColors{}); err == nil {
+			t.Errorf("ParseColor(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestAnsi256ToRGBCubeAndGrayscale(t *testing.T) {
+	r, g, b := ansi256ToRGB(16)
+	if r != 0 || g != 0 || b != 0 {
+		t.Errorf("ansi256ToRGB(16) = (%d,%d,%d), want (0,0,0)", r, g, b)
+	}
+
+	r, g, b = ansi256ToRGB(255)
+	if r != g || g != b || r != 238 {
+		t.Errorf("ansi256ToRGB(255) = (%d,%d,%d), want a gray of 238", r, g, b)
+	}
+}
+
+func TestScanAestheticsFileReportsColorErrorWithLineAndColumn(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aesthetics.conf")
+	contents := "[colors.background]\nprimary = \"not-a-color\"\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	config := &AestheticsConfig{}
+	err := scanAestheticsFile(config, path, false)
+	if err == nil {
+		t.Fatal("scanAestheticsFile succeeded, want error for an invalid color")
+	}
+	if !strings.Contains(err.Error(), path+":2:") {
+		t.Errorf("error %q doesn't report line 2 of %q", err, path)
+	}
+}
+
+func TestScanAestheticsFileReportsColumnAtValue(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aesthetics.conf")
+	contents := "[colors.background]\nprimary = \"not-a-color\"\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	config := &AestheticsConfig{}
+	err := scanAestheticsFile(config, path, false)
+	if err == nil {
+		t.Fatal("scanAestheticsFile succeeded, want error for an invalid color")
+	}
+	if !strings.Contains(err.Error(), path+":2:11:") {
+		t.Errorf("error %q doesn't point at column 11 (the value, not the \"=\")", err)
+	}
+}
+
+func TestScanAestheticsFileAllowsForwardAccentReference(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aesthetics.conf")
+	// The reference to accent.blue appears before [colors.accent] defines
+	// it -- sections can appear in any order, so this must not fail.
+	contents := "[colors.background]\nprimary = \"accent.blue\"\n\n[colors.accent]\nblue = \"#83a598\"\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	config := &AestheticsConfig{}
+	if err := scanAestheticsFile(config, path, false); err != nil {
+		t.Fatalf("scanAestheticsFile failed on a forward accent reference: %v", err)
+	}
+	if config.Colors.Background.Primary != "accent.blue" {
+		t.Errorf("Background.Primary = %q, want the reference stored as-is", config.Colors.Background.Primary)
+	}
+
+	resolved, err := ParseColor(config.Colors.Background.Primary, config.Colors.Accent)
+	if err != nil {
+		t.Fatalf("ParseColor failed to resolve the reference after the full file was scanned: %v", err)
+	}
+	if resolved.Hex() != "#83a598" {
+		t.Errorf("resolved.Hex() = %q, want #83a598", resolved.Hex())
+	}
+}
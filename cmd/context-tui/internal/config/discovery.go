@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// aestheticsFilename is the name LoadAestheticsConfig searches for in
+// StylesetSearchDirs when no explicit path is given.
+const aestheticsFilename = "aesthetics.conf"
+
+// legacyConfigPaths are tried, in order, after StylesetSearchDirs comes
+// up empty, for compatibility with setups that predate styleset
+// discovery and just drop aesthetics.conf next to the binary or repo.
+func legacyConfigPaths() []string {
+	paths := []string{filepath.Join("config", aestheticsFilename)}
+	if execPath, err := os.Executable(); err == nil {
+		paths = append(paths, filepath.Join(filepath.Dir(execPath), "..", "..", "config", aestheticsFilename))
+	}
+	return paths
+}
+
+// StylesetSearchDirs returns, in priority order, the directories
+// consulted for aesthetics.conf and named stylesets when no explicit
+// path is given: $XDG_CONFIG_HOME/nvim-hoverfloat, ~/.config/nvim-hoverfloat,
+// and every colon-separated entry in $NVIM_HOVERFLOAT_STYLESETS_DIRS (a
+// site- or package-wide location for shared stylesets).
+func StylesetSearchDirs() []string {
+	var dirs []string
+
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		dirs = append(dirs, filepath.Join(xdg, "nvim-hoverfloat"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".config", "nvim-hoverfloat"))
+	}
+	if extra := os.Getenv("NVIM_HOVERFLOAT_STYLESETS_DIRS"); extra != "" {
+		for _, dir := range strings.Split(extra, ":") {
+			if dir = strings.TrimSpace(dir); dir != "" {
+				dirs = append(dirs, expandTilde(dir))
+			}
+		}
+	}
+
+	return dirs
+}
+
+// expandTilde replaces a leading "~" or "~/" in path with the current
+// user's home directory, leaving path unchanged if it can't be resolved
+// or doesn't start with one.
+func expandTilde(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// resolveConfigPath locates the main aesthetics config file: explicit,
+// if given, else the first StylesetSearchDirs hit, else each of
+// legacyConfigPaths. It returns every path it tried, in order, so
+// callers can report all of them when none exist.
+func resolveConfigPath(explicit string) (path string, tried []string, err error) {
+	if explicit != "" {
+		explicit = expandTilde(explicit)
+		if _, statErr := os.Stat(explicit); statErr == nil {
+			return explicit, nil, nil
+		}
+		return "", []string{explicit}, fmt.Errorf("not found")
+	}
+
+	for _, dir := range StylesetSearchDirs() {
+		candidate := filepath.Join(dir, aestheticsFilename)
+		tried = append(tried, candidate)
+		if _, statErr := os.Stat(candidate); statErr == nil {
+			return candidate, nil, nil
+		}
+	}
+
+	for _, candidate := range legacyConfigPaths() {
+		tried = append(tried, candidate)
+		if _, statErr := os.Stat(candidate); statErr == nil {
+			return candidate, nil, nil
+		}
+	}
+
+	return "", tried, fmt.Errorf("not found")
+}
+
+// resolveStyleset locates the .conf file a `styleset-name` key refers
+// to, searching StylesetSearchDirs for "<name>.conf" (or "<name>" if it
+// already ends in .conf). It returns every path it tried, in order.
+func resolveStyleset(name string) (path string, tried []string, err error) {
+	filename := name
+	if !strings.HasSuffix(filename, ".conf") {
+		filename += ".conf"
+	}
+
+	for _, dir := range StylesetSearchDirs() {
+		candidate := filepath.Join(dir, filename)
+		tried = append(tried, candidate)
+		if _, statErr := os.Stat(candidate); statErr == nil {
+			return candidate, nil, nil
+		}
+	}
+
+	return "", tried, fmt.Errorf("not found")
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExpandTilde(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skipf("no home directory available: %v", err)
+	}
+
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"~", home},
+		{"~/themes", filepath.Join(home, "themes")},
+		{"/etc/nvim-hoverfloat", "/etc/nvim-hoverfloat"},
+		{"relative/path", "relative/path"},
+	}
+
+	for _, c := range cases {
+		if got := expandTilde(c.in); got != c.want {
+			t.Errorf("expandTilde(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestResolveConfigPathExplicitTakesPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	explicit := filepath.Join(dir, "custom.conf")
+	if err := os.WriteFile(explicit, []byte(""), 0o644); err != nil {
+		t.Fatalf("failed to write explicit config: %v", err)
+	}
+
+	got, tried, err := resolveConfigPath(explicit)
+	if err != nil {
+		t.Fatalf("resolveConfigPath failed: %v", err)
+	}
+	if got != explicit {
+		t.Errorf("resolveConfigPath = %q, want %q", got, explicit)
+	}
+	if tried != nil {
+		t.Errorf("tried = %v, want nil when the explicit path resolves", tried)
+	}
+}
+
+func TestResolveConfigPathExplicitMissingReportsItself(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.conf")
+
+	_, tried, err := resolveConfigPath(missing)
+	if err == nil {
+		t.Fatal("resolveConfigPath succeeded, want error for a missing explicit path")
+	}
+	if len(tried) != 1 || tried[0] != missing {
+		t.Errorf("tried = %v, want [%q]", tried, missing)
+	}
+}
+
+func TestResolveConfigPathFindsXDGDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	nvimDir := filepath.Join(dir, "nvim-hoverfloat")
+	if err := os.MkdirAll(nvimDir, 0o755); err != nil {
+		t.Fatalf("failed to create XDG dir: %v", err)
+	}
+	want := filepath.Join(nvimDir, aestheticsFilename)
+	if err := os.WriteFile(want, []byte(""), 0o644); err != nil {
+		t.Fatalf("failed to write aesthetics.conf: %v", err)
+	}
+
+	got, _, err := resolveConfigPath("")
+	if err != nil {
+		t.Fatalf("resolveConfigPath failed: %v", err)
+	}
+	if got != want {
+		t.Errorf("resolveConfigPath = %q, want %q", got, want)
+	}
+}
+
+func TestResolveConfigPathReportsEveryTriedPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("NVIM_HOVERFLOAT_STYLESETS_DIRS", "")
+
+	_, tried, err := resolveConfigPath("")
+	if err == nil {
+		t.Fatal("resolveConfigPath succeeded, want error when nothing exists")
+	}
+	if len(tried) == 0 {
+		t.Error("tried is empty, want every searched path listed")
+	}
+	for _, p := range tried {
+		if !strings.HasSuffix(p, aestheticsFilename) {
+			t.Errorf("tried entry %q doesn't end in %q", p, aestheticsFilename)
+		}
+	}
+}
+
+func TestLoadAestheticsConfigLayersStyleset(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	nvimDir := filepath.Join(dir, "nvim-hoverfloat")
+	if err := os.MkdirAll(nvimDir, 0o755); err != nil {
+		t.Fatalf("failed to create XDG dir: %v", err)
+	}
+
+	main := "styleset-name = gruvbox\n\n[colors.background]\nprimary = \"#000000\"\n"
+	if err := os.WriteFile(filepath.Join(nvimDir, aestheticsFilename), []byte(main), 0o644); err != nil {
+		t.Fatalf("failed to write main config: %v", err)
+	}
+
+	styleset := "[colors.background]\nprimary = \"#282828\"\nsecondary = \"#3c3836\"\n"
+	if err := os.WriteFile(filepath.Join(nvimDir, "gruvbox.conf"), []byte(styleset), 0o644); err != nil {
+		t.Fatalf("failed to write styleset: %v", err)
+	}
+
+	got, err := LoadAestheticsConfig("", false)
+	if err != nil {
+		t.Fatalf("LoadAestheticsConfig failed: %v", err)
+	}
+	if got.Colors.Background.Primary != "#282828" {
+		t.Errorf("Background.Primary = %q, want the styleset's value to override the main file's", got.Colors.Background.Primary)
+	}
+	if got.Colors.Background.Secondary != "#3c3836" {
+		t.Errorf("Background.Secondary = %q, want the styleset's value", got.Colors.Background.Secondary)
+	}
+}
+
+func TestResolveStylesetAppendsConfSuffix(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	nvimDir := filepath.Join(dir, "nvim-hoverfloat")
+	if err := os.MkdirAll(nvimDir, 0o755); err != nil {
+		t.Fatalf("failed to create XDG dir: %v", err)
+	}
+	want := filepath.Join(nvimDir, "gruvbox.conf")
+	if err := os.WriteFile(want, []byte(""), 0o644); err != nil {
+		t.Fatalf("failed to write styleset: %v", err)
+	}
+
+	got, _, err := resolveStyleset("gruvbox")
+	if err != nil {
+		t.Fatalf("resolveStyleset failed: %v", err)
+	}
+	if got != want {
+		t.Errorf("resolveStyleset = %q, want %q", got, want)
+	}
+}
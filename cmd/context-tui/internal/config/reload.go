@@ -0,0 +1,132 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchAestheticsConfig resolves configPath exactly as LoadAestheticsConfig
+// does, then watches the resolved file (and its styleset-name file, if
+// any) for changes until ctx is done. Each change is re-parsed and, if it
+// parses cleanly, atomically published via Config.Store, so a concurrent
+// renderer reading Config.Load() never observes a torn struct; a reload
+// is also announced on the returned channel so the TUI can trigger a
+// redraw. A reload that fails to parse is logged to stderr and the
+// previous Config is left in place -- critical for iterating on a theme
+// without restarting the hover process over a momentary syntax error.
+//
+// The parent directory of each watched file is what's actually added to
+// the watcher, not the file itself: editors commonly save by writing a
+// new inode and renaming it over the original (editor-swap), which would
+// silently drop a watch held on the old inode.
+func WatchAestheticsConfig(ctx context.Context, configPath string) (<-chan struct{}, error) {
+	if strings.HasPrefix(configPath, themePathPrefix) {
+		return nil, fmt.Errorf("cannot watch %q: a built-in theme isn't backed by a file", configPath)
+	}
+
+	resolved, tried, err := resolveConfigPath(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("aesthetics.conf not found, tried: %s", strings.Join(tried, ", "))
+	}
+
+	watchedFiles := []string{resolved}
+	if config := Config.Load(); config != nil && config.StyleSetName != "" {
+		if styleset, _, err := resolveStyleset(config.StyleSetName); err == nil {
+			watchedFiles = append(watchedFiles, styleset)
+		}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start config watcher: %w", err)
+	}
+
+	watchedDirs := map[string]bool{}
+	for _, f := range watchedFiles {
+		watchedDirs[filepath.Dir(f)] = true
+	}
+	for dir := range watchedDirs {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return nil, fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+	}
+
+	changed := make(chan struct{}, 1)
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !watchesPath(watchedFiles, event.Name) || !isReloadEvent(event) {
+					continue
+				}
+				reloadAndPublish(resolved, changed)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				fmt.Fprintf(os.Stderr, "config: watch error: %v\n", err)
+			}
+		}
+	}()
+
+	return changed, nil
+}
+
+// watchesPath reports whether name refers to one of files.
+func watchesPath(files []string, name string) bool {
+	for _, f := range files {
+		if filepath.Clean(name) == filepath.Clean(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReloadEvent reports whether event is worth reparsing for: a write to
+// the file in place, or a create/rename, which is what an editor-swap
+// save looks like from the directory watch's point of view. The paired
+// Remove half of an editor-swap is ignored since the following Create
+// already covers it.
+func isReloadEvent(event fsnotify.Event) bool {
+	return event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0
+}
+
+// reloadAndPublish re-parses resolved in strict mode -- collecting every
+// problem rather than stopping at the first, since an edit in progress
+// often has more than one -- and, if it parses and validates cleanly,
+// atomically publishes it as the new Config and announces the change on
+// changed (non-blocking, since a reader that's behind on one redraw will
+// pick up the latest Config on the next one anyway). On failure it logs
+// and leaves the previous Config in place.
+func reloadAndPublish(resolved string, changed chan<- struct{}) {
+	config, err := loadFromResolvedPath(resolved, true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: reload of %s failed, keeping previous config: %v\n", resolved, err)
+		return
+	}
+
+	if verrs := config.Validate(); verrs.HasErrors() {
+		fmt.Fprintf(os.Stderr, "config: reload of %s failed validation, keeping previous config:\n%v\n", resolved, verrs)
+		return
+	}
+
+	Config.Store(config)
+
+	select {
+	case changed <- struct{}{}:
+	default:
+	}
+}
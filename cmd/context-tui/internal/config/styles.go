@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StyleSpec is one named style layer, parsed from a `[styles.<name>]`
+// section. Fg/Bg/Bold/Italic/Underline/Reverse are pointers so a layer
+// that never mentions a field leaves it unset rather than overriding it
+// with a zero value -- mergeStyleSpec only overlays fields the source
+// actually set. Inherits names other styles to resolve and merge in
+// first, so a style can build on a shared base before adding its own
+// overrides.
+type StyleSpec struct {
+	Fg        *string  `json:"fg,omitempty"`
+	Bg        *string  `json:"bg,omitempty"`
+	Bold      *bool    `json:"bold,omitempty"`
+	Italic    *bool    `json:"italic,omitempty"`
+	Underline *bool    `json:"underline,omitempty"`
+	Reverse   *bool    `json:"reverse,omitempty"`
+	Inherits  []string `json:"inherits,omitempty"`
+}
+
+// mergeStyleSpec returns dst with every field src explicitly sets
+// overlaid on top of it, so a later, more specific layer wins field by
+// field rather than replacing the whole style.
+func mergeStyleSpec(dst, src StyleSpec) StyleSpec {
+	if src.Fg != nil {
+		dst.Fg = src.Fg
+	}
+	if src.Bg != nil {
+		dst.Bg = src.Bg
+	}
+	if src.Bold != nil {
+		dst.Bold = src.Bold
+	}
+	if src.Italic != nil {
+		dst.Italic = src.Italic
+	}
+	if src.Underline != nil {
+		dst.Underline = src.Underline
+	}
+	if src.Reverse != nil {
+		dst.Reverse = src.Reverse
+	}
+	return dst
+}
+
+// resolveNamed resolves name's StyleSpec, merging its Inherits (in order)
+// before overlaying its own fields, and following chains of inheritance
+// transitively. seen guards against an inherits cycle; a name already in
+// seen is skipped rather than erroring, since a style layer missing
+// entirely is just treated as empty.
+func (c *AestheticsConfig) resolveNamed(name string, seen map[string]bool) StyleSpec {
+	var resolved StyleSpec
+	if seen[name] {
+		return resolved
+	}
+	seen[name] = true
+
+	spec, ok := c.Styles[name]
+	if !ok {
+		return resolved
+	}
+
+	for _, parent := range spec.Inherits {
+		resolved = mergeStyleSpec(resolved, c.resolveNamed(parent, seen))
+	}
+	return mergeStyleSpec(resolved, spec)
+}
+
+// ResolveStyle resolves "default" as the base, then overlays layers in
+// the order given, mirroring aerc's msglist layering: the most specific
+// layer wins a field, but a layer that only sets fg, say, still lets an
+// earlier layer's bold stand. A layer name with no matching [styles.*]
+// section contributes nothing.
+func (c *AestheticsConfig) ResolveStyle(layers ...string) StyleSpec {
+	result := c.resolveNamed("default", map[string]bool{})
+	for _, layer := range layers {
+		result = mergeStyleSpec(result, c.resolveNamed(layer, map[string]bool{}))
+	}
+	return result
+}
+
+func setStylesValue(config *AestheticsConfig, name, key, value string) error {
+	if name == "" {
+		return fmt.Errorf("styles section requires a name, e.g. [styles.symbol_error]")
+	}
+	if config.Styles == nil {
+		config.Styles = make(map[string]StyleSpec)
+	}
+	spec := config.Styles[name]
+
+	switch key {
+	case "fg":
+		spec.Fg = strPtr(value)
+	case "bg":
+		spec.Bg = strPtr(value)
+	case "bold":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid bold value %q: %w", value, err)
+		}
+		spec.Bold = &b
+	case "italic":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid italic value %q: %w", value, err)
+		}
+		spec.Italic = &b
+	case "underline":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid underline value %q: %w", value, err)
+		}
+		spec.Underline = &b
+	case "reverse":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid reverse value %q: %w", value, err)
+		}
+		spec.Reverse = &b
+	case "inherits":
+		var inherits []string
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				inherits = append(inherits, part)
+			}
+		}
+		spec.Inherits = inherits
+	default:
+		return fmt.Errorf("unknown style key: %s", key)
+	}
+
+	config.Styles[name] = spec
+	return nil
+}
+
+func strPtr(s string) *string { return &s }
@@ -0,0 +1,97 @@
+package config
+
+import "testing"
+
+func strOf(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return *s
+}
+
+func boolOf(b *bool) string {
+	if b == nil {
+		return "<nil>"
+	}
+	if *b {
+		return "true"
+	}
+	return "false"
+}
+
+func TestResolveStyleLayersOverridePartially(t *testing.T) {
+	c := &AestheticsConfig{
+		Styles: map[string]StyleSpec{
+			"default":           {Fg: strPtr("fg0"), Bg: strPtr("bg0"), Bold: boolPtr(false)},
+			"symbol_default":    {Fg: strPtr("fg1")},
+			"symbol_unresolved": {Italic: boolPtr(true)},
+			"symbol_error":      {Fg: strPtr("fg3"), Bold: boolPtr(true)},
+		},
+	}
+
+	got := c.ResolveStyle("symbol_default", "symbol_unresolved", "symbol_error")
+
+	if strOf(got.Fg) != "fg3" {
+		t.Errorf("Fg = %s, want fg3 (most specific layer should win)", strOf(got.Fg))
+	}
+	if strOf(got.Bg) != "bg0" {
+		t.Errorf("Bg = %s, want bg0 (inherited from default, no layer overrides it)", strOf(got.Bg))
+	}
+	if boolOf(got.Bold) != "true" {
+		t.Errorf("Bold = %s, want true (symbol_error sets it)", boolOf(got.Bold))
+	}
+	if boolOf(got.Italic) != "true" {
+		t.Errorf("Italic = %s, want true (symbol_unresolved sets it, nothing later overrides)", boolOf(got.Italic))
+	}
+}
+
+func TestResolveStyleMissingLayerContributesNothing(t *testing.T) {
+	c := &AestheticsConfig{
+		Styles: map[string]StyleSpec{
+			"default": {Fg: strPtr("fg0")},
+		},
+	}
+
+	got := c.ResolveStyle("symbol_focused")
+
+	if strOf(got.Fg) != "fg0" {
+		t.Errorf("Fg = %s, want fg0 unchanged by an undefined layer", strOf(got.Fg))
+	}
+}
+
+func TestResolveStyleFollowsInherits(t *testing.T) {
+	c := &AestheticsConfig{
+		Styles: map[string]StyleSpec{
+			"default":        {},
+			"border_base":    {Fg: strPtr("grey")},
+			"border_focused": {Inherits: []string{"border_base"}, Bold: boolPtr(true)},
+		},
+	}
+
+	got := c.ResolveStyle("border_focused")
+
+	if strOf(got.Fg) != "grey" {
+		t.Errorf("Fg = %s, want grey (inherited from border_base)", strOf(got.Fg))
+	}
+	if boolOf(got.Bold) != "true" {
+		t.Errorf("Bold = %s, want true (border_focused's own field)", boolOf(got.Bold))
+	}
+}
+
+func TestResolveStyleIgnoresInheritsCycle(t *testing.T) {
+	c := &AestheticsConfig{
+		Styles: map[string]StyleSpec{
+			"default": {},
+			"a":       {Inherits: []string{"b"}, Fg: strPtr("fgA")},
+			"b":       {Inherits: []string{"a"}, Fg: strPtr("fgB")},
+		},
+	}
+
+	got := c.ResolveStyle("a")
+
+	if strOf(got.Fg) != "fgA" {
+		t.Errorf("Fg = %s, want fgA (a's own field wins even with a cyclic inherits chain)", strOf(got.Fg))
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
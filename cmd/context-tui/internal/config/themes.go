@@ -0,0 +1,62 @@
+package config
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//go:embed themes/*.conf
+var embeddedThemesFS embed.FS
+
+// embeddedThemeDir is embeddedThemesFS's top-level directory, matching
+// the go:embed pattern above.
+const embeddedThemeDir = "themes"
+
+// themePathPrefix is the sentinel LoadAestheticsConfig's configPath
+// recognizes as "load this built-in theme from the embedded FS instead
+// of a file on disk", e.g. "theme:gruvbox-dark".
+const themePathPrefix = "theme:"
+
+// ListThemes returns the name of every theme bundled with the binary
+// (without its .conf suffix), sorted, for a future :HoverFloatTheme
+// picker command and for reporting an unknown base_theme/theme: name.
+func ListThemes() []string {
+	entries, err := embeddedThemesFS.ReadDir(embeddedThemeDir)
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".conf"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadEmbeddedTheme parses the bundled theme named name the same way a
+// file on disk is parsed, failing fast on the first bad line -- a
+// built-in theme ships with the binary, so a syntax error in one is a
+// bug in this repo, not something a strict-mode error list helps a user
+// fix.
+func loadEmbeddedTheme(name string) (*AestheticsConfig, error) {
+	return loadEmbeddedThemeStrict(name, false)
+}
+
+// loadEmbeddedThemeStrict is loadEmbeddedTheme with strict mode exposed,
+// for "theme:<name>" pseudo-paths passed to LoadAestheticsConfig, which
+// should honor the caller's strict flag like any other load.
+func loadEmbeddedThemeStrict(name string, strict bool) (*AestheticsConfig, error) {
+	data, err := embeddedThemesFS.ReadFile(embeddedThemeDir + "/" + name + ".conf")
+	if err != nil {
+		return nil, fmt.Errorf("unknown built-in theme %q (available: %s)", name, strings.Join(ListThemes(), ", "))
+	}
+
+	config := &AestheticsConfig{}
+	if err := scanAestheticsBytes(config, data, themePathPrefix+name, strict); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
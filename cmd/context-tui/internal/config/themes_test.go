@@ -0,0 +1,87 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListThemesIncludesAllBundledThemes(t *testing.T) {
+	want := []string{
+		"catppuccin-mocha",
+		"gruvbox-dark",
+		"gruvbox-light",
+		"nord",
+		"solarized-dark",
+		"tokyonight",
+	}
+
+	got := ListThemes()
+	if len(got) != len(want) {
+		t.Fatalf("ListThemes() = %v, want %v", got, want)
+	}
+	for i, name := range want {
+		if got[i] != name {
+			t.Errorf("ListThemes()[%d] = %q, want %q", i, got[i], name)
+		}
+	}
+}
+
+func TestLoadAestheticsConfigLoadsEmbeddedTheme(t *testing.T) {
+	got, err := LoadAestheticsConfig("theme:nord", false)
+	if err != nil {
+		t.Fatalf("LoadAestheticsConfig(theme:nord) failed: %v", err)
+	}
+	if got.Colors.Background.Primary != "#2e3440" {
+		t.Errorf("Background.Primary = %q, want the embedded nord theme's value", got.Colors.Background.Primary)
+	}
+}
+
+func TestLoadAestheticsConfigRejectsUnknownTheme(t *testing.T) {
+	if _, err := LoadAestheticsConfig("theme:does-not-exist", false); err == nil {
+		t.Error("LoadAestheticsConfig(theme:does-not-exist) succeeded, want an error")
+	}
+}
+
+func TestBaseThemePrePopulatesThenUserKeysOverride(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aesthetics.conf")
+	contents := "base_theme = \"gruvbox-dark\"\n\n[colors.background]\nprimary = \"#000000\"\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	got, err := LoadAestheticsConfig(path, false)
+	if err != nil {
+		t.Fatalf("LoadAestheticsConfig failed: %v", err)
+	}
+	if got.Colors.Background.Primary != "#000000" {
+		t.Errorf("Background.Primary = %q, want the user's override #000000", got.Colors.Background.Primary)
+	}
+	if got.Colors.Foreground.Primary != "#ebdbb2" {
+		t.Errorf("Foreground.Primary = %q, want gruvbox-dark's unmodified value #ebdbb2", got.Colors.Foreground.Primary)
+	}
+	if got.BaseTheme != "gruvbox-dark" {
+		t.Errorf("BaseTheme = %q, want %q", got.BaseTheme, "gruvbox-dark")
+	}
+}
+
+func TestBaseThemeRejectsUnknownName(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aesthetics.conf")
+	contents := "base_theme = \"does-not-exist\"\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	if _, err := LoadAestheticsConfig(path, false); err == nil {
+		t.Error("LoadAestheticsConfig with an unknown base_theme succeeded, want an error")
+	}
+}
+
+func TestWatchAestheticsConfigRejectsEmbeddedTheme(t *testing.T) {
+	if _, err := WatchAestheticsConfig(context.Background(), "theme:nord"); err == nil {
+		t.Error("WatchAestheticsConfig(theme:nord) succeeded, want an error since there's no file to watch")
+	}
+}
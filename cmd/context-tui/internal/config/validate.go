@@ -0,0 +1,168 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ValidationErrors collects every problem found while validating an
+// AestheticsConfig (or scanning one in strict mode), so a user editing a
+// theme sees the whole list at once instead of fixing one mistake per
+// restart. Warnings are non-fatal -- an omitted required color, say --
+// and don't affect HasErrors.
+type ValidationErrors struct {
+	Errs     []error
+	Warnings []string
+}
+
+func (e *ValidationErrors) addError(err error) {
+	e.Errs = append(e.Errs, err)
+}
+
+func (e *ValidationErrors) addWarning(format string, args ...any) {
+	e.Warnings = append(e.Warnings, fmt.Sprintf(format, args...))
+}
+
+// HasErrors reports whether any error (as opposed to warning) was
+// recorded.
+func (e *ValidationErrors) HasErrors() bool {
+	return len(e.Errs) > 0
+}
+
+// Error renders every collected error, one per line, so the top-level
+// failure a caller sees already lists everything wrong with the file.
+func (e *ValidationErrors) Error() string {
+	lines := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// requiredColorFields names the colors every theme must set: the base
+// primary background and foreground everything else renders on top of.
+// Anything else is optional and just falls back to the zero value (an
+// empty lipgloss.Color, which lipgloss treats as "no color").
+var requiredColorFields = []string{
+	"colors.background.primary",
+	"colors.foreground.primary",
+}
+
+// Validate checks c for problems a successful parse doesn't catch: that
+// every color field (including accent references, now that the whole
+// file -- and any styleset layered on top of it -- has been scanned)
+// resolves to an actual color, that MinWidth <= MaxWidth, that every
+// padding/spacing/dimension field is non-negative, and that the colors a
+// theme can't reasonably omit weren't left unset. It collects every
+// problem rather than stopping at the first.
+func (c *AestheticsConfig) Validate() *ValidationErrors {
+	v := &ValidationErrors{}
+
+	c.validateColors(v)
+	c.validateDimensions(v)
+	c.validateNonNegative(v)
+
+	return v
+}
+
+// colorFields returns every ColorConfig leaf as dotted-path name ->
+// spec, so validateColors and the required-field check below can walk
+// them uniformly instead of hand-rolling the same switch ParseColor
+// already has to do.
+func (c *AestheticsConfig) colorFields() map[string]string {
+	return map[string]string{
+		"colors.background.primary":    c.Colors.Background.Primary,
+		"colors.background.secondary":  c.Colors.Background.Secondary,
+		"colors.background.accent":     c.Colors.Background.Accent,
+		"colors.background.floating":   c.Colors.Background.Floating,
+		"colors.background.code_block": c.Colors.Background.CodeBlock,
+		"colors.background.selection":  c.Colors.Background.Selection,
+		"colors.foreground.primary":    c.Colors.Foreground.Primary,
+		"colors.foreground.secondary":  c.Colors.Foreground.Secondary,
+		"colors.foreground.comment":    c.Colors.Foreground.Comment,
+		"colors.foreground.dark":       c.Colors.Foreground.Dark,
+		"colors.foreground.inverse":    c.Colors.Foreground.Inverse,
+		"colors.accent.blue":           c.Colors.Accent.Blue,
+		"colors.accent.green":          c.Colors.Accent.Green,
+		"colors.accent.yellow":         c.Colors.Accent.Yellow,
+		"colors.accent.purple":         c.Colors.Accent.Purple,
+		"colors.accent.red":            c.Colors.Accent.Red,
+		"colors.accent.orange":         c.Colors.Accent.Orange,
+		"colors.accent.cyan":           c.Colors.Accent.Cyan,
+		"colors.accent.pink":           c.Colors.Accent.Pink,
+		"colors.semantic.border":       c.Colors.Semantic.Border,
+		"colors.semantic.border_dim":   c.Colors.Semantic.BorderDim,
+		"colors.semantic.focus":        c.Colors.Semantic.Focus,
+		"colors.semantic.error":        c.Colors.Semantic.Error,
+		"colors.semantic.warning":      c.Colors.Semantic.Warning,
+		"colors.semantic.success":      c.Colors.Semantic.Success,
+		"colors.semantic.info":         c.Colors.Semantic.Info,
+	}
+}
+
+func (c *AestheticsConfig) validateColors(v *ValidationErrors) {
+	fields := c.colorFields()
+
+	for _, name := range sortedKeys(fields) {
+		value := fields[name]
+		if value == "" {
+			continue
+		}
+		// Resolved against the config's actual accents rather than
+		// placeholderAccents: every section has been scanned by now, so
+		// an accent.<name> reference should resolve for real, not just
+		// parse syntactically.
+		if _, err := ParseColor(value, c.Colors.Accent); err != nil {
+			v.addError(fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	for _, name := range requiredColorFields {
+		if fields[name] == "" {
+			v.addWarning("%s is not set", name)
+		}
+	}
+}
+
+func (c *AestheticsConfig) validateDimensions(v *ValidationErrors) {
+	d := c.Layout.Dimensions
+	if d.MinWidth > 0 && d.MaxWidth > 0 && d.MinWidth > d.MaxWidth {
+		v.addError(fmt.Errorf("layout.dimensions: min_width (%d) is greater than max_width (%d)", d.MinWidth, d.MaxWidth))
+	}
+}
+
+// validateNonNegative checks every padding/spacing/dimension field for a
+// negative value -- none of them mean anything as a negative number, and
+// a typo'd sign is otherwise silently rendered as zero-width by lipgloss.
+func (c *AestheticsConfig) validateNonNegative(v *ValidationErrors) {
+	fields := map[string]int{
+		"layout.spacing.section_margin_bottom":   c.Layout.Spacing.SectionMarginBottom,
+		"layout.spacing.header_padding":          c.Layout.Spacing.HeaderPadding,
+		"layout.spacing.footer_padding":          c.Layout.Spacing.FooterPadding,
+		"layout.spacing.content_padding":         c.Layout.Spacing.ContentPadding,
+		"layout.dimensions.min_width":            c.Layout.Dimensions.MinWidth,
+		"layout.dimensions.max_width":            c.Layout.Dimensions.MaxWidth,
+		"layout.dimensions.default_height":       c.Layout.Dimensions.DefaultHeight,
+		"formatting.sections.padding_horizontal": c.Formatting.Sections.PaddingHorizontal,
+		"formatting.sections.padding_vertical":   c.Formatting.Sections.PaddingVertical,
+	}
+
+	for _, name := range sortedKeys(fields) {
+		if n := fields[name]; n < 0 {
+			v.addError(fmt.Errorf("%s: must be non-negative, got %d", name, n))
+		}
+	}
+}
+
+// sortedKeys returns m's keys in sorted order, so validation errors are
+// reported in a stable, readable order instead of Go's randomized map
+// iteration order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
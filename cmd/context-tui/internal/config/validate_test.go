@@ -0,0 +1,120 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateRejectsMinWidthGreaterThanMaxWidth(t *testing.T) {
+	config := &AestheticsConfig{}
+	config.Layout.Dimensions.MinWidth = 100
+	config.Layout.Dimensions.MaxWidth = 50
+
+	verrs := config.Validate()
+	if !verrs.HasErrors() {
+		t.Fatal("Validate() reported no errors, want one for min_width > max_width")
+	}
+}
+
+func TestValidateRejectsNegativePadding(t *testing.T) {
+	config := &AestheticsConfig{}
+	config.Formatting.Sections.PaddingHorizontal = -1
+
+	verrs := config.Validate()
+	if !verrs.HasErrors() {
+		t.Fatal("Validate() reported no errors, want one for a negative padding")
+	}
+}
+
+func TestValidateWarnsOnMissingRequiredColors(t *testing.T) {
+	config := &AestheticsConfig{}
+
+	verrs := config.Validate()
+	if verrs.HasErrors() {
+		t.Fatalf("Validate() reported errors for an empty config, want only warnings: %v", verrs)
+	}
+	if len(verrs.Warnings) == 0 {
+		t.Fatal("Validate() reported no warnings, want one for each missing required color")
+	}
+}
+
+func TestValidateRejectsMalformedColor(t *testing.T) {
+	config := &AestheticsConfig{}
+	config.Colors.Background.Primary = "not-a-color"
+
+	verrs := config.Validate()
+	if !verrs.HasErrors() {
+		t.Fatal("Validate() reported no errors, want one for a malformed color")
+	}
+}
+
+func TestValidateResolvesAccentReferencesAgainstRealAccents(t *testing.T) {
+	config := &AestheticsConfig{}
+	config.Colors.Background.Primary = "accent.blue"
+	config.Colors.Accent.Blue = "#83a598"
+	config.Colors.Foreground.Primary = "#ffffff"
+
+	verrs := config.Validate()
+	if verrs.HasErrors() {
+		t.Fatalf("Validate() reported errors for a resolvable accent reference: %v", verrs)
+	}
+}
+
+func TestScanAestheticsFileStrictCollectsAllErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aesthetics.conf")
+	contents := "[colors.background]\nprimary = \"not-a-color\"\nsecondary = \"also-not-a-color\"\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	config := &AestheticsConfig{}
+	err := scanAestheticsFile(config, path, true)
+	if err == nil {
+		t.Fatal("scanAestheticsFile succeeded, want errors for two invalid colors")
+	}
+
+	verrs, ok := err.(*ValidationErrors)
+	if !ok {
+		t.Fatalf("scanAestheticsFile returned %T, want *ValidationErrors", err)
+	}
+	if len(verrs.Errs) != 2 {
+		t.Errorf("len(verrs.Errs) = %d, want 2 (one per bad line)", len(verrs.Errs))
+	}
+	if !strings.Contains(verrs.Error(), path+":2:") || !strings.Contains(verrs.Error(), path+":3:") {
+		t.Errorf("verrs.Error() = %q, want both line 2 and line 3 reported", verrs.Error())
+	}
+}
+
+func TestScanAestheticsFileNonStrictStopsAtFirstError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aesthetics.conf")
+	contents := "[colors.background]\nprimary = \"not-a-color\"\nsecondary = \"also-not-a-color\"\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	config := &AestheticsConfig{}
+	err := scanAestheticsFile(config, path, false)
+	if err == nil {
+		t.Fatal("scanAestheticsFile succeeded, want an error for the first invalid color")
+	}
+	if strings.Contains(err.Error(), path+":3:") {
+		t.Errorf("non-strict scanAestheticsFile reported line 3, want it to stop at the first error (line 2)")
+	}
+}
+
+func TestLoadAestheticsConfigStrictSurfacesBadIntegerValue(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aesthetics.conf")
+	contents := "[layout.dimensions]\nmin_width = not-a-number\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	if _, err := LoadAestheticsConfig(path, true); err == nil {
+		t.Fatal("LoadAestheticsConfig(strict) succeeded, want an error for a non-numeric min_width")
+	}
+}
@@ -0,0 +1,216 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// rpcRequest is a JSON-RPC 2.0 request frame.
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int64       `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response frame.
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int64           `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("lsp error %d: %s", e.Code, e.Message)
+}
+
+// Client speaks JSON-RPC 2.0 over a language server's stdio pipes, using the
+// standard "Content-Length:" header framing from the LSP spec.
+type Client struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	nextID int64
+
+	mu      sync.Mutex
+	pending map[int64]chan *rpcResponse
+}
+
+// NewClient spawns the given language server command and starts its
+// read loop. The caller is responsible for sending "initialize" before
+// issuing any other request.
+func NewClient(command string, args ...string) (*Client, error) {
+	cmd := exec.Command(command, args...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stdin pipe: %v", err)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stdout pipe: %v", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start language server %q: %v", command, err)
+	}
+
+	c := &Client{
+		cmd:     cmd,
+		stdin:   stdin,
+		pending: make(map[int64]chan *rpcResponse),
+	}
+
+	go c.readLoop(stdout)
+
+	return c, nil
+}
+
+// Close terminates the language server process.
+func (c *Client) Close() error {
+	c.stdin.Close()
+	return c.cmd.Process.Kill()
+}
+
+// Call issues a request and blocks until the matching response arrives,
+// decoding its result into v.
+func (c *Client) Call(method string, params interface{}, v interface{}) error {
+	id := atomic.AddInt64(&c.nextID, 1)
+
+	ch := make(chan *rpcResponse, 1)
+	c.mu.Lock()
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	req := rpcRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params}
+	if err := c.writeFrame(req); err != nil {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+		return err
+	}
+
+	resp := <-ch
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if v == nil || len(resp.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, v)
+}
+
+// Notify sends a one-way notification (no response expected).
+func (c *Client) Notify(method string, params interface{}) error {
+	return c.writeFrame(struct {
+		JSONRPC string      `json:"jsonrpc"`
+		Method  string      `json:"method"`
+		Params  interface{} `json:"params,omitempty"`
+	}{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func (c *Client) writeFrame(v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	header := fmt.Sprintf("Content-Length: %d\r\n\r\n", len(body))
+	if _, err := io.WriteString(c.stdin, header); err != nil {
+		return err
+	}
+	_, err = c.stdin.Write(body)
+	return err
+}
+
+func (c *Client) readLoop(r io.Reader) {
+	reader := bufio.NewReader(r)
+
+	for {
+		contentLength, err := readHeaders(reader)
+		if err != nil {
+			c.failAllPending(err)
+			return
+		}
+
+		body := make([]byte, contentLength)
+		if _, err := io.ReadFull(reader, body); err != nil {
+			c.failAllPending(err)
+			return
+		}
+
+		var resp rpcResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			// Malformed frame (or a server->client request we don't
+			// handle yet); drop it and keep reading.
+			continue
+		}
+		if resp.ID == 0 {
+			// Notification from the server, e.g. publishDiagnostics;
+			// not wired up here.
+			continue
+		}
+
+		c.mu.Lock()
+		ch, ok := c.pending[resp.ID]
+		delete(c.pending, resp.ID)
+		c.mu.Unlock()
+
+		if ok {
+			ch <- &resp
+		}
+	}
+}
+
+func (c *Client) failAllPending(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, ch := range c.pending {
+		ch <- &rpcResponse{Error: &rpcError{Code: -1, Message: err.Error()}}
+		delete(c.pending, id)
+	}
+}
+
+// readHeaders consumes the LSP header block and returns Content-Length.
+func readHeaders(r *bufio.Reader) (int, error) {
+	contentLength := -1
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return 0, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			break
+		}
+
+		if name, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return 0, fmt.Errorf("invalid Content-Length header %q: %v", value, err)
+			}
+			contentLength = n
+		}
+	}
+
+	if contentLength < 0 {
+		return 0, fmt.Errorf("missing Content-Length header")
+	}
+	return contentLength, nil
+}
@@ -0,0 +1,134 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadHeadersParsesContentLength(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("Content-Length: 42\r\n\r\n"))
+
+	n, err := readHeaders(r)
+	if err != nil {
+		t.Fatalf("readHeaders failed: %v", err)
+	}
+	if n != 42 {
+		t.Errorf("Content-Length = %d, want 42", n)
+	}
+}
+
+func TestReadHeadersCaseInsensitiveAndExtraHeaders(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("Content-Type: application/vscode-jsonrpc\r\ncontent-length: 7\r\n\r\n"))
+
+	n, err := readHeaders(r)
+	if err != nil {
+		t.Fatalf("readHeaders failed: %v", err)
+	}
+	if n != 7 {
+		t.Errorf("Content-Length = %d, want 7", n)
+	}
+}
+
+func TestReadHeadersMissingContentLengthErrors(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("Content-Type: application/vscode-jsonrpc\r\n\r\n"))
+
+	if _, err := readHeaders(r); err == nil {
+		t.Error("Expected readHeaders to error without a Content-Length header")
+	}
+}
+
+func TestWriteFrameWritesLSPHeaderAndBody(t *testing.T) {
+	var buf bytes.Buffer
+	c := &Client{stdin: nopCloser{&buf}, pending: make(map[int64]chan *rpcResponse)}
+
+	if err := c.writeFrame(rpcRequest{JSONRPC: "2.0", ID: 1, Method: "initialize"}); err != nil {
+		t.Fatalf("writeFrame failed: %v", err)
+	}
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize"}`
+	want := fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(body), body)
+	if buf.String() != want {
+		t.Errorf("writeFrame wrote %q, want %q", buf.String(), want)
+	}
+}
+
+func TestReadLoopDeliversResponseToPendingChannel(t *testing.T) {
+	body := `{"jsonrpc":"2.0","id":1,"result":{"ok":true}}`
+	frame := fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(body), body)
+
+	c := &Client{pending: make(map[int64]chan *rpcResponse)}
+	ch := make(chan *rpcResponse, 1)
+	c.pending[1] = ch
+
+	go c.readLoop(strings.NewReader(frame))
+
+	select {
+	case resp := <-ch:
+		if resp.Error != nil {
+			t.Fatalf("unexpected error in response: %v", resp.Error)
+		}
+		if string(resp.Result) != `{"ok":true}` {
+			t.Errorf("Result = %s, want {\"ok\":true}", resp.Result)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("readLoop did not deliver the response")
+	}
+}
+
+func TestReadLoopDropsNotificationsAndUnmatchedResponses(t *testing.T) {
+	notification := `{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics","params":{}}`
+	unmatched := `{"jsonrpc":"2.0","id":99,"result":{}}`
+	matched := `{"jsonrpc":"2.0","id":1,"result":{"ok":true}}`
+
+	var buf bytes.Buffer
+	for _, body := range []string{notification, unmatched, matched} {
+		fmt.Fprintf(&buf, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	}
+
+	c := &Client{pending: make(map[int64]chan *rpcResponse)}
+	ch := make(chan *rpcResponse, 1)
+	c.pending[1] = ch
+
+	go c.readLoop(&buf)
+
+	select {
+	case resp := <-ch:
+		if string(resp.Result) != `{"ok":true}` {
+			t.Errorf("Result = %s, want {\"ok\":true}", resp.Result)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("readLoop did not deliver the matched response past the dropped frames")
+	}
+}
+
+func TestFailAllPendingDeliversErrorToEveryWaiter(t *testing.T) {
+	c := &Client{pending: make(map[int64]chan *rpcResponse)}
+	ch1 := make(chan *rpcResponse, 1)
+	ch2 := make(chan *rpcResponse, 1)
+	c.pending[1] = ch1
+	c.pending[2] = ch2
+
+	c.failAllPending(fmt.Errorf("server exited"))
+
+	for _, ch := range []chan *rpcResponse{ch1, ch2} {
+		select {
+		case resp := <-ch:
+			if resp.Error == nil {
+				t.Error("Expected failAllPending to deliver an error response")
+			}
+		default:
+			t.Error("Expected failAllPending to deliver to every pending channel")
+		}
+	}
+	if len(c.pending) != 0 {
+		t.Errorf("Expected pending map to be drained, got %d entries", len(c.pending))
+	}
+}
+
+type nopCloser struct{ *bytes.Buffer }
+
+func (nopCloser) Close() error { return nil }
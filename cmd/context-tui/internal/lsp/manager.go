@@ -0,0 +1,217 @@
+package lsp
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ServerConfig maps a filetype to the command used to launch its language
+// server, e.g. {"go": {"gopls"}, "rust": {"rust-analyzer"}}.
+type ServerConfig struct {
+	Command string
+	Args    []string
+}
+
+// Manager owns one Client per filetype, starting servers lazily on first
+// use so the TUI doesn't pay startup cost for languages the user never
+// touches in a session.
+type Manager struct {
+	configs map[string]ServerConfig
+
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+// NewManager builds a Manager from a filetype->ServerConfig map.
+func NewManager(configs map[string]ServerConfig) *Manager {
+	return &Manager{
+		configs: configs,
+		clients: make(map[string]*Client),
+	}
+}
+
+// DefaultServerConfigs returns a reasonable starter mapping covering the
+// language servers most commonly already on a developer's PATH.
+func DefaultServerConfigs() map[string]ServerConfig {
+	return map[string]ServerConfig{
+		"go":         {Command: "gopls", Args: []string{"serve"}},
+		"rust":       {Command: "rust-analyzer"},
+		"python":     {Command: "pyright-langserver", Args: []string{"--stdio"}},
+		"typescript": {Command: "typescript-language-server", Args: []string{"--stdio"}},
+		"lua":        {Command: "lua-language-server"},
+	}
+}
+
+// FiletypeFromPath derives a best-effort filetype from a file extension,
+// used to look up the configured server when the caller doesn't already
+// know the buffer's filetype.
+func FiletypeFromPath(path string) string {
+	switch strings.TrimPrefix(filepath.Ext(path), ".") {
+	case "go":
+		return "go"
+	case "rs":
+		return "rust"
+	case "py":
+		return "python"
+	case "ts", "tsx", "js", "jsx":
+		return "typescript"
+	case "lua":
+		return "lua"
+	default:
+		return ""
+	}
+}
+
+// ClientFor returns the running client for the given filetype, starting a
+// new server process on first use.
+func (m *Manager) ClientFor(filetype string) (*Client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.clients[filetype]; ok {
+		return c, nil
+	}
+
+	cfg, ok := m.configs[filetype]
+	if !ok {
+		return nil, fmt.Errorf("no language server configured for filetype %q", filetype)
+	}
+
+	client, err := NewClient(cfg.Command, cfg.Args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start %q server: %v", filetype, err)
+	}
+
+	if err := client.Call("initialize", map[string]interface{}{
+		"processId": nil,
+		"rootUri":   nil,
+		"capabilities": map[string]interface{}{},
+	}, nil); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("initialize failed for %q: %v", filetype, err)
+	}
+	if err := client.Notify("initialized", map[string]interface{}{}); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("initialized notification failed for %q: %v", filetype, err)
+	}
+
+	m.clients[filetype] = client
+	return client, nil
+}
+
+// Close shuts down every running server.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for filetype, client := range m.clients {
+		client.Close()
+		delete(m.clients, filetype)
+	}
+}
+
+// Requests bundles the textDocument/* calls the TUI issues itself instead
+// of depending on the Neovim plugin to precompute them.
+
+func pathToURI(path string) string {
+	return "file://" + path
+}
+
+func posParams(uri string, line, col int) TextDocumentPositionParams {
+	// LSP positions are zero-based; the socket protocol's Line/Col are
+	// already 1-based from the editor, so offset by one.
+	return TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     Position{Line: line - 1, Character: col - 1},
+	}
+}
+
+// Hover issues textDocument/hover for the given file position.
+func (m *Manager) Hover(filetype, path string, line, col int) (*Hover, error) {
+	client, err := m.ClientFor(filetype)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Hover
+	if err := client.Call("textDocument/hover", posParams(pathToURI(path), line, col), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Definition issues textDocument/definition.
+func (m *Manager) Definition(filetype, path string, line, col int) ([]Location, error) {
+	return m.locationRequest("textDocument/definition", filetype, path, line, col)
+}
+
+// TypeDefinition issues textDocument/typeDefinition.
+func (m *Manager) TypeDefinition(filetype, path string, line, col int) ([]Location, error) {
+	return m.locationRequest("textDocument/typeDefinition", filetype, path, line, col)
+}
+
+// References issues textDocument/references.
+func (m *Manager) References(filetype, path string, line, col int, includeDeclaration bool) ([]Location, error) {
+	client, err := m.ClientFor(filetype)
+	if err != nil {
+		return nil, err
+	}
+
+	params := ReferenceParams{
+		TextDocumentPositionParams: posParams(pathToURI(path), line, col),
+		Context:                    ReferenceContext{IncludeDeclaration: includeDeclaration},
+	}
+
+	var result []Location
+	if err := client.Call("textDocument/references", params, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (m *Manager) locationRequest(method, filetype, path string, line, col int) ([]Location, error) {
+	client, err := m.ClientFor(filetype)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Location
+	if err := client.Call(method, posParams(pathToURI(path), line, col), &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Completion issues textDocument/completion and returns the raw item list;
+// fuzzy filtering against the user's in-progress prefix happens in the
+// view layer so it can rerun cheaply as the user types.
+func (m *Manager) Completion(filetype, path string, line, col int) (*CompletionList, error) {
+	client, err := m.ClientFor(filetype)
+	if err != nil {
+		return nil, err
+	}
+
+	params := CompletionParams{TextDocumentPositionParams: posParams(pathToURI(path), line, col)}
+
+	var result CompletionList
+	if err := client.Call("textDocument/completion", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SignatureHelp issues textDocument/signatureHelp.
+func (m *Manager) SignatureHelp(filetype, path string, line, col int) (*SignatureHelp, error) {
+	client, err := m.ClientFor(filetype)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SignatureHelp
+	if err := client.Call("textDocument/signatureHelp", posParams(pathToURI(path), line, col), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,118 @@
+package lsp
+
+// Minimal subset of the LSP types we need to drive hover/definition/
+// references/completion/signatureHelp requests ourselves instead of relying
+// on the Neovim plugin to push pre-formatted context.
+
+// Position is a zero-based line/character offset, per the LSP spec.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range spans from Start to End within a single document.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// TextDocumentIdentifier identifies the buffer a request applies to.
+type TextDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+// TextDocumentPositionParams is the shared params shape for hover,
+// definition, typeDefinition and references requests.
+type TextDocumentPositionParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Position     Position               `json:"position"`
+}
+
+// ReferenceContext toggles whether the declaration itself is included.
+type ReferenceContext struct {
+	IncludeDeclaration bool `json:"includeDeclaration"`
+}
+
+// ReferenceParams extends TextDocumentPositionParams for textDocument/references.
+type ReferenceParams struct {
+	TextDocumentPositionParams
+	Context ReferenceContext `json:"context"`
+}
+
+// CompletionParams extends TextDocumentPositionParams for textDocument/completion.
+type CompletionParams struct {
+	TextDocumentPositionParams
+}
+
+// Location is a file + range, as returned by definition/typeDefinition/references.
+type Location struct {
+	URI   string `json:"uri"`
+	Range Range  `json:"range"`
+}
+
+// MarkupContent carries hover text, usually as markdown.
+type MarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// Hover is the result of textDocument/hover.
+type Hover struct {
+	Contents MarkupContent `json:"contents"`
+	Range    *Range        `json:"range,omitempty"`
+}
+
+// CompletionItem mirrors the fields the TUI renders in the completion popup.
+type CompletionItem struct {
+	Label           string     `json:"label"`
+	Kind            int        `json:"kind,omitempty"`
+	Detail          string     `json:"detail,omitempty"`
+	Documentation   string     `json:"documentation,omitempty"`
+	InsertText      string     `json:"insertText,omitempty"`
+	AdditionalEdits []TextEdit `json:"additionalTextEdits,omitempty"`
+	FilterText      string     `json:"filterText,omitempty"`
+}
+
+// TextEdit replaces the text within Range with NewText.
+type TextEdit struct {
+	Range   Range  `json:"range"`
+	NewText string `json:"newText"`
+}
+
+// CompletionList is the result of textDocument/completion.
+type CompletionList struct {
+	IsIncomplete bool             `json:"isIncomplete"`
+	Items        []CompletionItem `json:"items"`
+}
+
+// SignatureInformation describes one overload in a signature help response.
+type SignatureInformation struct {
+	Label         string `json:"label"`
+	Documentation string `json:"documentation,omitempty"`
+}
+
+// SignatureHelp is the result of textDocument/signatureHelp.
+type SignatureHelp struct {
+	Signatures      []SignatureInformation `json:"signatures"`
+	ActiveSignature int                    `json:"activeSignature"`
+	ActiveParameter int                    `json:"activeParameter"`
+}
+
+// Diagnostic severity levels, per the textDocument/publishDiagnostics spec.
+const (
+	SeverityError       = 1
+	SeverityWarning     = 2
+	SeverityInformation = 3
+	SeverityHint        = 4
+)
+
+// Diagnostic mirrors the fields the TUI renders in the Diagnostics
+// section. Code is interface{} because the spec allows it to be either a
+// number or a string depending on the server.
+type Diagnostic struct {
+	Range    Range       `json:"range"`
+	Severity int         `json:"severity,omitempty"`
+	Code     interface{} `json:"code,omitempty"`
+	Source   string      `json:"source,omitempty"`
+	Message  string      `json:"message"`
+}
@@ -2,22 +2,69 @@ package model
 
 import (
 	"bufio"
+	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	mathrand "math/rand"
 	"net"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sahilm/fuzzy"
+	"nvim-hoverfloat/cmd/context-tui/internal/auth"
+	"nvim-hoverfloat/cmd/context-tui/internal/config"
+	"nvim-hoverfloat/cmd/context-tui/internal/lsp"
+	"nvim-hoverfloat/cmd/context-tui/internal/panes"
+	"nvim-hoverfloat/cmd/context-tui/internal/recorder"
+	"nvim-hoverfloat/cmd/context-tui/internal/sinks"
 	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+	"nvim-hoverfloat/cmd/context-tui/internal/socket/channel"
+	"nvim-hoverfloat/cmd/context-tui/internal/socket/frame"
 	"nvim-hoverfloat/cmd/context-tui/internal/styles"
 	"nvim-hoverfloat/cmd/context-tui/internal/view"
 )
 
+// trustLabel identifies the Neovim plugin client in the TOFU known_clients
+// store. Every accepted connection, whichever session it becomes, is
+// checked against the key recorded under this one fixed label.
+const trustLabel = "nvim"
+
+// pingWriteTimeout bounds how long a pong write may take before it's
+// abandoned, so a stalled client can't block the ping/pong loop that
+// keeps the connection's liveness state accurate.
+const pingWriteTimeout = 5 * time.Second
+
+// reconnectBaseDelay and reconnectMaxDelay bound the jittered exponential
+// backoff between the ReconnectStatusMsg ticks a Reconnecting session
+// emits while it waits for its client to redial.
+const (
+	reconnectBaseDelay = 250 * time.Millisecond
+	reconnectMaxDelay  = 10 * time.Second
+)
+
+// reconnectBackoff returns the jittered delay before reconnect status tick
+// n (1-indexed): reconnectBaseDelay doubled each attempt up to
+// reconnectMaxDelay, with up to 20% jitter so several sessions dropping at
+// once don't all wake and redial in lockstep.
+func reconnectBackoff(attempt int) time.Duration {
+	delay := reconnectMaxDelay
+	if shift := attempt - 1; shift < 32 {
+		if scaled := reconnectBaseDelay << uint(shift); scaled > 0 && scaled < reconnectMaxDelay {
+			delay = scaled
+		}
+	}
+	jitter := time.Duration(mathrand.Int63n(int64(delay)/5 + 1))
+	return delay - time.Duration(int64(delay)/10) + jitter
+}
+
 // FocusArea represents the currently focused section
 type FocusArea int
 
@@ -26,6 +73,8 @@ const (
 	FocusReferences
 	FocusDefinition
 	FocusTypeDefinition
+	FocusCompletionItems
+	FocusDiagnostics
 )
 
 // SelectionMode represents text selection state
@@ -59,6 +108,22 @@ type ViewportReadyMsg struct {
 	Area FocusArea
 }
 
+// AestheticsChangedMsg is sent whenever the watched aesthetics.conf
+// styleset is edited and re-parses cleanly, so Update can rebuild
+// m.styles from the newly published config.Config.
+type AestheticsChangedMsg struct{}
+type CompletionResultMsg struct {
+	Items []lsp.CompletionItem
+}
+
+// ReconnectStatusMsg reports a Reconnecting session's progress waiting for
+// its client to redial, so the status line can show the retry count and
+// when the next one is due instead of just a static "Reconnecting" label.
+type ReconnectStatusMsg struct {
+	Attempt     int
+	NextAttempt time.Time
+}
+
 // App represents the main application model with enhanced interactivity
 type App struct {
 	// Display state
@@ -72,12 +137,20 @@ type App struct {
 	ErrorMsg   string
 
 	// Interactive state
-	Focus          FocusArea
-	ShowHover      bool
-	ShowReferences bool
-	ShowDefinition bool
-	ShowTypeInfo   bool
-	SectionHeights map[FocusArea]int // Dynamic section heights
+	Focus               FocusArea
+	ShowHover           bool
+	ShowReferences      bool
+	ShowDefinition      bool
+	ShowTypeInfo        bool
+	ShowCompletionItems bool
+	ShowDiagnostics     bool
+	SectionHeights      map[FocusArea]int // Dynamic section heights
+	SectionWidths       map[FocusArea]int // Dynamic section widths (horizontal split)
+
+	// paneManager is the authoritative source of section geometry;
+	// SectionHeights/SectionWidths above are recomputed from it whenever
+	// the layout, focus, or visible set changes.
+	paneManager *panes.Manager
 
 	// Selection state
 	SelectionMode  SelectionMode
@@ -86,41 +159,116 @@ type App struct {
 	SelectedText   string
 
 	// Viewports for scrollable content
-	HoverViewport      viewport.Model
-	ReferencesViewport viewport.Model
-	DefinitionViewport viewport.Model
-	TypeInfoViewport   viewport.Model
-	viewportsReady     bool
+	HoverViewport           viewport.Model
+	ReferencesViewport      viewport.Model
+	DefinitionViewport      viewport.Model
+	TypeInfoViewport        viewport.Model
+	CompletionItemsViewport viewport.Model
+	DiagnosticsViewport     viewport.Model
+	viewportsReady          bool
 
 	// Socket communication
 	socketPath        string
+	transport         Transport
 	socketListener    net.Listener
 	clientConn        net.Conn
+	clientChannel     channel.Channel
 	connMutex         sync.RWMutex
 	connectionState   ConnectionState
 	messageBridge     *MessageBridge
 	heartbeatTimer    *time.Timer
 	connectionTimeout time.Duration
 
+	// Reconnection: a session whose connection drops without an explicit
+	// disconnect/session_bye is given reconnectWindow to redial (see
+	// enterReconnecting) before it's given up on. idleTimeout bounds how
+	// long a Reconnecting session may linger unclaimed before the
+	// periodic reapIdleConnections sweep (CloseIdleConnections) drops it
+	// for good, a backstop independent of any single session's timer.
+	reconnectWindow time.Duration
+	idleTimeout     time.Duration
+
+	// reconnectAttempt/reconnectNextAttempt mirror the most recent
+	// ReconnectStatusMsg, so View can render the retry count and ETA
+	// without threading them through as a separate message field.
+	reconnectAttempt     int
+	reconnectNextAttempt time.Time
+
+	// pendingRequests tracks outbound Messages awaiting a reply whose
+	// InReplyTo echoes them, so Request can expose a synchronous call on
+	// top of the otherwise one-way, asynchronous persistent connection.
+	pendingRequests *socket.PendingRequests
+
+	// Sessions holds every concurrently attached Neovim connection, keyed
+	// by a session ID the TUI assigns at accept time. activeSession is
+	// which one's Context/Channel are currently mirrored onto
+	// m.Context/m.clientChannel for the rest of the UI; sessionSeq hands
+	// out the next ID. pendingSessionPrefix remembers a lone "]" or "["
+	// keypress until the following key resolves it to a ]s/[s binding.
+	Sessions             *SessionRegistry
+	activeSession        uint32
+	sessionSeq           uint32
+	pendingSessionPrefix rune
+
 	// Readiness handling
 	readinessHandled bool
 
+	// LSP subsystem: the TUI issues its own hover/definition/references/
+	// completion/signatureHelp requests rather than relying solely on the
+	// Neovim plugin to push pre-formatted context.
+	lspManager *lsp.Manager
+	Completion CompletionState
+
+	// RefFinder drives the `/`-triggered fuzzy filter over References.
+	RefFinder ReferenceFinder
+
+	// TOFU trust state for the socket: knownClients is the persisted
+	// fingerprint store, and trustViolation/pendingFingerprint drive the
+	// accept/reject prompt when a connecting client's key doesn't match
+	// the one recorded on first use.
+	knownClients       *auth.KnownClients
+	trustViolation     bool
+	pendingFingerprint string
+
+	// Session recording/replay (see internal/recorder): recorder, when
+	// set, appends every inbound socket message to disk; replayPlayer,
+	// when set, replaces the live socket server as the source of
+	// ContextUpdateMsg traffic entirely.
+	recorder     *recorder.Recorder
+	replayPlayer *recorder.Player
+
+	// contextSinks fan out every accepted ContextUpdateMsg, in addition to
+	// the viewport update it drives (see internal/sinks).
+	contextSinks []sinks.Sink
+
 	// Styles
 	styles *styles.Styles
 }
 
+// TrustViolationMsg is sent when a connecting client's key fingerprint
+// doesn't match the one trusted on first use, so the user can accept or
+// reject it via the menu system rather than the socket silently trusting
+// (or refusing) the connection on its own.
+type TrustViolationMsg struct {
+	Fingerprint string
+}
+
 // Context represents LSP context data
 type Context struct {
-	File            string                `json:"file"`
-	Line            int                   `json:"line"`
-	Col             int                   `json:"col"`
-	Timestamp       int64                 `json:"timestamp"`
-	Hover           []string              `json:"hover,omitempty"`
-	Definition      *socket.LocationInfo  `json:"definition,omitempty"`
-	ReferencesCount int                   `json:"references_count,omitempty"`
-	References      []socket.LocationInfo `json:"references,omitempty"`
-	ReferencesMore  int                   `json:"references_more,omitempty"`
-	TypeDefinition  *socket.LocationInfo  `json:"type_definition,omitempty"`
+	File            string                  `json:"file"`
+	Line            int                     `json:"line"`
+	Col             int                     `json:"col"`
+	Timestamp       int64                   `json:"timestamp"`
+	Hover           socket.HoverContent     `json:"hover,omitempty"`
+	Definition      *socket.LocationInfo    `json:"definition,omitempty"`
+	ReferencesCount int                     `json:"references_count,omitempty"`
+	References      []socket.LocationInfo   `json:"references,omitempty"`
+	ReferencesMore  int                     `json:"references_more,omitempty"`
+	TypeDefinition  *socket.LocationInfo    `json:"type_definition,omitempty"`
+	CompletionItems []lsp.CompletionItem    `json:"completion_items,omitempty"`
+	Diagnostics     []lsp.Diagnostic        `json:"diagnostics,omitempty"`
+	SignatureHelp   *lsp.SignatureHelp      `json:"signature_help,omitempty"`
+	CodeActions     []socket.CodeActionInfo `json:"code_actions,omitempty"`
 }
 
 // MessageBridge handles thread-safe communication
@@ -162,34 +310,186 @@ func (mb *MessageBridge) CheckMessages() tea.Cmd {
 // NewApp creates a new application model
 func NewApp(socketPath string) *App {
 	app := &App{
-		socketPath:        socketPath,
-		ShowHover:         true,
-		ShowReferences:    true,
-		ShowDefinition:    true,
-		ShowTypeInfo:      true,
-		Focus:             FocusHover,
-		styles:            styles.New(),
-		messageBridge:     NewMessageBridge(),
-		connectionState:   Disconnected,
-		connectionTimeout: 30 * time.Second,
-		SectionHeights:    make(map[FocusArea]int),
-		SelectionMode:     SelectionNone,
-	}
-
-	// Initialize default section heights
-	app.SectionHeights[FocusHover] = 10
-	app.SectionHeights[FocusReferences] = 8
-	app.SectionHeights[FocusDefinition] = 4
-	app.SectionHeights[FocusTypeDefinition] = 4
+		socketPath:          socketPath,
+		transport:           TransportUnix,
+		ShowHover:           true,
+		ShowReferences:      true,
+		ShowDefinition:      true,
+		ShowTypeInfo:        true,
+		ShowCompletionItems: true,
+		ShowDiagnostics:     true,
+		Focus:               FocusHover,
+		styles:              styles.New(),
+		messageBridge:       NewMessageBridge(),
+		connectionState:     Disconnected,
+		connectionTimeout:   30 * time.Second,
+		reconnectWindow:     15 * time.Second,
+		idleTimeout:         30 * time.Second,
+		SectionHeights:      make(map[FocusArea]int),
+		SectionWidths:       make(map[FocusArea]int),
+		SelectionMode:       SelectionNone,
+		lspManager:          lsp.NewManager(lsp.DefaultServerConfigs()),
+		paneManager:         loadOrDefaultPaneManager(),
+		knownClients:        loadOrDefaultKnownClients(),
+		pendingRequests:     socket.NewPendingRequests(),
+		Sessions:            NewSessionRegistry(),
+	}
 
 	return app
 }
 
+func loadOrDefaultKnownClients() *auth.KnownClients {
+	kc, err := auth.Load(auth.DefaultPath())
+	if err != nil {
+		return auth.NewKnownClients()
+	}
+	return kc
+}
+
+// EnableRecording makes every inbound socket message also get appended to
+// path, for later replay via EnableReplay. Call before Init.
+func (m *App) EnableRecording(path string) error {
+	rec, err := recorder.NewRecorder(path)
+	if err != nil {
+		return err
+	}
+	m.recorder = rec
+	return nil
+}
+
+// SetSinks parses specs (the --sink flag's value, e.g.
+// "jsonl:/tmp/ctx.jsonl,console") and installs the resulting sinks, so
+// every accepted context update is also written to them. Call before
+// Init.
+func (m *App) SetSinks(specs string) error {
+	parsed, err := sinks.ParseSpecs(specs)
+	if err != nil {
+		return err
+	}
+	m.contextSinks = parsed
+	return nil
+}
+
+// CloseSinks closes every installed sink, flushing any buffered output.
+func (m *App) CloseSinks() {
+	for _, s := range m.contextSinks {
+		s.Close()
+	}
+}
+
+// SetTheme loads the named built-in (or user-overridden) theme and applies
+// it immediately, overriding NVIM_HOVERFLOAT_THEME. Call before Init.
+func (m *App) SetTheme(name string) error {
+	theme, err := styles.LoadTheme(name)
+	if err != nil {
+		return err
+	}
+	m.styles = styles.NewWithTheme(theme)
+	return nil
+}
+
+// SetAesthetics loads the aesthetics.conf styleset at path (or, if path
+// is empty, wherever config.StylesetSearchDirs finds one) and rebuilds
+// m.styles so its [styles.*] layers are applied on top of the active
+// theme. It then watches the resolved file for changes, so editing a
+// styleset while the TUI is running re-applies it without a restart.
+// Call before Init.
+func (m *App) SetAesthetics(path string) error {
+	cfg, err := config.LoadAestheticsConfig(path, false)
+	if err != nil {
+		return err
+	}
+	config.Config.Store(cfg)
+	m.styles = styles.NewWithTheme(m.styles.Theme)
+
+	changed, err := config.WatchAestheticsConfig(context.Background(), path)
+	if err != nil {
+		// Nothing to watch, e.g. a "theme:" pseudo-path -- the config
+		// still applies, it just won't hot-reload.
+		return nil
+	}
+	go func() {
+		for range changed {
+			m.messageBridge.SendMessage(AestheticsChangedMsg{})
+		}
+	}()
+	return nil
+}
+
+// Transport identifies which wire transport newly accepted connections
+// negotiate onto after the shared TOFU handshake and proto/msize
+// negotiation, which both happen over the control Unix socket regardless
+// of transport.
+type Transport string
+
+const (
+	// TransportUnix is the FrameChannel-over-net.Conn path every
+	// connection has always used.
+	TransportUnix Transport = "unix"
+
+	// TransportSHM hands the connection off to an mmapped, eventfd-backed
+	// ring-buffer Channel (see socket/shm) instead, trading the extra
+	// setup cost of a one-time handshake for lower steady-state
+	// cursor-move latency. Linux only.
+	TransportSHM Transport = "shm"
+)
+
+// SetTransport validates name and, if it's a known transport (unix or
+// shm), sets it as the transport newly accepted connections negotiate
+// onto. It doesn't check whether this build can serve shm; that surfaces
+// per connection from negotiateShmChannel.
+// Already-connected sessions are unaffected. Call before Init.
+func (m *App) SetTransport(name string) error {
+	switch Transport(name) {
+	case TransportUnix, TransportSHM:
+		m.transport = Transport(name)
+		return nil
+	default:
+		return fmt.Errorf("unknown transport %q (want unix or shm)", name)
+	}
+}
+
+// EnableReplay switches the app from listening on the socket to replaying
+// a previously recorded session from path, at its original pacing scaled
+// by speed and looping forever if loop is set. Call before Init.
+func (m *App) EnableReplay(path string, speed float64, loop bool) error {
+	player, err := recorder.NewPlayer(path, speed, loop)
+	if err != nil {
+		return err
+	}
+	m.replayPlayer = player
+	return nil
+}
+
+// paneIDs returns the stable pane identifiers in FocusArea order, used to
+// persist layout across sessions regardless of which sections currently
+// have data.
+var paneIDs = []string{"hover", "references", "definition", "typedefinition", "completion_items", "diagnostics"}
+
+func focusPaneID(area FocusArea) string {
+	return paneIDs[area]
+}
+
+func loadOrDefaultPaneManager() *panes.Manager {
+	if m, err := panes.Load(panes.DefaultLayoutPath()); err == nil {
+		return m
+	}
+	return panes.NewManager(paneIDs...)
+}
+
 // Init initializes the application
 func (m *App) Init() tea.Cmd {
 	// Send readiness signal immediately via a proper file descriptor
 	m.signalReadiness()
 
+	if m.replayPlayer != nil {
+		return tea.Batch(
+			m.startReplay(),
+			tea.EnterAltScreen,
+			m.messageBridge.CheckMessages(),
+		)
+	}
+
 	return tea.Batch(
 		m.startSocketServer(),
 		tea.EnterAltScreen,
@@ -197,6 +497,24 @@ func (m *App) Init() tea.Cmd {
 	)
 }
 
+// startReplay feeds a previously recorded session's messages back into the
+// program as ContextUpdateMsg, in place of a live socket connection.
+func (m *App) startReplay() tea.Cmd {
+	return func() tea.Msg {
+		go m.runReplay()
+		return ConnectionStateChangedMsg{State: Connected}
+	}
+}
+
+func (m *App) runReplay() {
+	m.replayPlayer.Play(func(msg socket.Message) {
+		if contextData, ok := msg.ExtractContextData(); ok {
+			m.messageBridge.SendMessage(socket.ContextUpdateMsg{Data: contextData})
+		}
+	})
+	m.messageBridge.SendMessage(ConnectionStateChangedMsg{State: Disconnected})
+}
+
 // signalReadiness sends readiness signal via a more reliable method
 func (m *App) signalReadiness() {
 	if m.readinessHandled {
@@ -246,6 +564,18 @@ func (m *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if cmd != nil {
 				cmds = append(cmds, cmd)
 			}
+		case FocusCompletionItems:
+			newItems, cmd := m.CompletionItemsViewport.Update(msg)
+			m.CompletionItemsViewport = newItems
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		case FocusDiagnostics:
+			newDiag, cmd := m.DiagnosticsViewport.Update(msg)
+			m.DiagnosticsViewport = newDiag
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 		}
 	}
 
@@ -268,6 +598,7 @@ func (m *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Context = convertSocketContextToModel(msg.Data)
 		m.LastUpdate = time.Now()
 		m.ErrorMsg = ""
+		m.fanOutToSinks(msg.Data)
 		m.updateViewportContent()
 		cmds = append(cmds, m.messageBridge.CheckMessages())
 		return m, tea.Batch(cmds...)
@@ -279,6 +610,27 @@ func (m *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case ConnectionStateChangedMsg:
 		m.setConnectionState(msg.State)
+		if msg.State == Connected {
+			m.reconnectAttempt = 0
+			m.reconnectNextAttempt = time.Time{}
+		}
+		cmds = append(cmds, m.messageBridge.CheckMessages())
+		return m, tea.Batch(cmds...)
+
+	case SessionSwitchedMsg:
+		m.applySessionSwitch(msg.ID)
+		cmds = append(cmds, m.messageBridge.CheckMessages())
+		return m, tea.Batch(cmds...)
+
+	case ReconnectStatusMsg:
+		m.reconnectAttempt = msg.Attempt
+		m.reconnectNextAttempt = msg.NextAttempt
+		cmds = append(cmds, m.messageBridge.CheckMessages())
+		return m, tea.Batch(cmds...)
+
+	case TrustViolationMsg:
+		m.trustViolation = true
+		m.pendingFingerprint = msg.Fingerprint
 		cmds = append(cmds, m.messageBridge.CheckMessages())
 		return m, tea.Batch(cmds...)
 
@@ -287,6 +639,16 @@ func (m *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, m.messageBridge.CheckMessages())
 		return m, tea.Batch(cmds...)
 
+	case AestheticsChangedMsg:
+		m.styles = styles.NewWithTheme(m.styles.Theme)
+		cmds = append(cmds, m.messageBridge.CheckMessages())
+		return m, tea.Batch(cmds...)
+
+	case CompletionResultMsg:
+		m.Completion.Open(msg.Items)
+		cmds = append(cmds, m.messageBridge.CheckMessages())
+		return m, tea.Batch(cmds...)
+
 	case ContinuePollingMsg:
 		cmds = append(cmds, m.messageBridge.CheckMessages())
 		return m, tea.Batch(cmds...)
@@ -297,25 +659,71 @@ func (m *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// recomputeLayout asks the pane manager for exact geometry for every
+// currently visible section and stores it in SectionHeights/SectionWidths,
+// replacing the old fixed-shrinking-height guesswork.
+func (m *App) recomputeLayout() {
+	contentHeight := m.Height - 6 // header + footer allowance
+	if contentHeight < 1 {
+		contentHeight = 1
+	}
+
+	visible := make([]string, 0, len(paneIDs))
+	for _, area := range m.getVisibleAreas() {
+		visible = append(visible, focusPaneID(area))
+	}
+	if len(visible) == 0 {
+		visible = paneIDs
+	}
+
+	rects := m.paneManager.Layout(visible, m.Width-4, contentHeight)
+
+	for area, id := range paneIDs {
+		rect, ok := rects[id]
+		if !ok {
+			continue
+		}
+		m.SectionHeights[FocusArea(area)] = rect.Height
+		m.SectionWidths[FocusArea(area)] = rect.Width
+	}
+}
+
 // initializeViewports sets up the viewports with proper dimensions
 func (m *App) initializeViewports() {
 	if m.Width == 0 || m.Height == 0 {
 		return
 	}
 
+	m.recomputeLayout()
+
+	width := func(area FocusArea) int {
+		if m.paneManager.Orientation == panes.Horizontal {
+			if w := m.SectionWidths[area]; w > 0 {
+				return w
+			}
+		}
+		return m.Width - 4
+	}
+
 	// Initialize each viewport
-	m.HoverViewport = viewport.New(m.Width-4, m.SectionHeights[FocusHover])
+	m.HoverViewport = viewport.New(width(FocusHover), m.SectionHeights[FocusHover])
 	m.HoverViewport.Style = m.styles.SectionContent
 
-	m.ReferencesViewport = viewport.New(m.Width-4, m.SectionHeights[FocusReferences])
+	m.ReferencesViewport = viewport.New(width(FocusReferences), m.SectionHeights[FocusReferences])
 	m.ReferencesViewport.Style = m.styles.SectionContent
 
-	m.DefinitionViewport = viewport.New(m.Width-4, m.SectionHeights[FocusDefinition])
+	m.DefinitionViewport = viewport.New(width(FocusDefinition), m.SectionHeights[FocusDefinition])
 	m.DefinitionViewport.Style = m.styles.SectionContent
 
-	m.TypeInfoViewport = viewport.New(m.Width-4, m.SectionHeights[FocusTypeDefinition])
+	m.TypeInfoViewport = viewport.New(width(FocusTypeDefinition), m.SectionHeights[FocusTypeDefinition])
 	m.TypeInfoViewport.Style = m.styles.SectionContent
 
+	m.CompletionItemsViewport = viewport.New(width(FocusCompletionItems), m.SectionHeights[FocusCompletionItems])
+	m.CompletionItemsViewport.Style = m.styles.SectionContent
+
+	m.DiagnosticsViewport = viewport.New(width(FocusDiagnostics), m.SectionHeights[FocusDiagnostics])
+	m.DiagnosticsViewport.Style = m.styles.SectionContent
+
 	m.viewportsReady = true
 	m.updateViewportContent()
 }
@@ -328,12 +736,14 @@ func (m *App) updateViewportContent() {
 
 	// Update hover viewport
 	if m.Context.Hover != nil && len(m.Context.Hover) > 0 {
-		hoverContent := strings.Join(m.Context.Hover, "\n")
+		hoverContent := strings.Join(m.Context.Hover.Lines(), "\n")
 		m.HoverViewport.SetContent(hoverContent)
 	}
 
 	// Update references viewport
-	if m.Context.References != nil && len(m.Context.References) > 0 {
+	if m.RefFinder.Active {
+		m.ReferencesViewport.SetContent(m.renderRefFinderContent())
+	} else if m.Context.References != nil && len(m.Context.References) > 0 {
 		var refLines []string
 		for i, ref := range m.Context.References {
 			refLines = append(refLines, fmt.Sprintf("%d. %s:%d:%d", i+1, ref.File, ref.Line, ref.Col))
@@ -361,6 +771,81 @@ func (m *App) updateViewportContent() {
 			m.Context.TypeDefinition.Col)
 		m.TypeInfoViewport.SetContent(typeContent)
 	}
+
+	// Update completion items viewport
+	if len(m.Context.CompletionItems) > 0 {
+		var itemLines []string
+		for _, item := range m.Context.CompletionItems {
+			if item.Detail != "" {
+				itemLines = append(itemLines, fmt.Sprintf("%s  %s", item.Label, m.styles.Comment.Render(item.Detail)))
+			} else {
+				itemLines = append(itemLines, item.Label)
+			}
+		}
+		m.CompletionItemsViewport.SetContent(strings.Join(itemLines, "\n"))
+	}
+
+	// Update diagnostics viewport
+	if len(m.Context.Diagnostics) > 0 {
+		var diagLines []string
+		for _, diag := range m.Context.Diagnostics {
+			line := fmt.Sprintf("%d:%d %s", diag.Range.Start.Line+1, diag.Range.Start.Character+1, diag.Message)
+			diagLines = append(diagLines, m.styles.PriorityColor(diag.Severity).Render(line))
+		}
+		m.DiagnosticsViewport.SetContent(strings.Join(diagLines, "\n"))
+	}
+}
+
+// fanOutToSinks writes data to every installed sink. Called once per
+// accepted ContextUpdateMsg, alongside the viewport update it drives.
+func (m *App) fanOutToSinks(data *socket.ContextData) {
+	for _, s := range m.contextSinks {
+		if err := s.WriteContext(data); err != nil {
+			m.ErrorMsg = fmt.Sprintf("sink write failed: %v", err)
+		}
+	}
+}
+
+// renderRefFinderContent renders the fuzzy-matched reference list with
+// matched segments highlighted via styles.Highlight.
+func (m *App) renderRefFinderContent() string {
+	lines := []string{fmt.Sprintf("/%s", m.RefFinder.Query)}
+
+	if len(m.RefFinder.Matches) == 0 {
+		lines = append(lines, "  no matches")
+		return strings.Join(lines, "\n")
+	}
+
+	for i, match := range m.RefFinder.Matches {
+		line := highlightMatch(match, m.styles)
+		if i == m.RefFinder.Selected {
+			line = "> " + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// highlightMatch renders a fuzzy.Match's string with the matched rune
+// indices styled via styles.Highlight, and the rest plain.
+func highlightMatch(match fuzzy.Match, s *styles.Styles) string {
+	matched := make(map[int]bool, len(match.MatchedIndexes))
+	for _, idx := range match.MatchedIndexes {
+		matched[idx] = true
+	}
+
+	var b strings.Builder
+	for i, r := range match.Str {
+		if matched[i] {
+			b.WriteString(s.Highlight.Render(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
 }
 
 // handleKeyPress processes keyboard input with enhanced functionality
@@ -368,6 +853,11 @@ func (m *App) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 	// Global keys
 	switch msg.String() {
 	case "ctrl+c", "q":
+		m.paneManager.Save(panes.DefaultLayoutPath())
+		if m.recorder != nil {
+			m.recorder.Close()
+		}
+		m.CloseSinks()
 		return tea.Quit
 	case "?", "f1":
 		// Toggle help menu
@@ -380,6 +870,60 @@ func (m *App) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 			fmt.Fprintf(os.Stderr, "Copied: %s\n", m.SelectedText)
 		}
 		return nil
+	case "p":
+		// Pause/resume a running --replay session; a no-op otherwise.
+		if m.replayPlayer != nil {
+			m.replayPlayer.TogglePause()
+		}
+		return nil
+	case ".":
+		// Step one frame forward while a --replay session is paused.
+		if m.replayPlayer != nil {
+			m.replayPlayer.Step()
+		}
+		return nil
+	case "t":
+		m.cycleTheme()
+		return nil
+	}
+
+	// ]s / [s cycle the active multiplexed session forward/backward, vim's
+	// usual "]" / "[" plus a letter convention. Neither half is bound to
+	// anything on its own, so a bare "]" or "[" is remembered and resolved
+	// against whatever key follows it.
+	if m.pendingSessionPrefix != 0 {
+		prefix := m.pendingSessionPrefix
+		m.pendingSessionPrefix = 0
+		if msg.String() == "s" {
+			if prefix == ']' {
+				m.cycleSession(1)
+			} else {
+				m.cycleSession(-1)
+			}
+			return nil
+		}
+	}
+	switch msg.String() {
+	case "]", "[":
+		m.pendingSessionPrefix = rune(msg.String()[0])
+		return nil
+	}
+
+	// A trust violation prompt takes priority over everything else: the
+	// user must explicitly accept or reject the new key before resuming
+	// normal interaction.
+	if m.trustViolation {
+		return m.handleTrustPromptKeys(msg)
+	}
+
+	// Completion popup handling takes priority over normal navigation.
+	if m.Completion.Visible {
+		return m.handleCompletionKeys(msg)
+	}
+
+	// Reference finder handling takes priority over normal navigation.
+	if m.RefFinder.Active {
+		return m.handleRefFinderKeys(msg)
 	}
 
 	// Selection mode handling
@@ -389,6 +933,14 @@ func (m *App) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 
 	// Normal mode navigation
 	switch msg.String() {
+	case "ctrl+space":
+		return m.requestCompletion()
+	case "/":
+		if m.Focus == FocusReferences && m.Context != nil && len(m.Context.References) > 0 {
+			m.RefFinder.Open(m.Context.References)
+			m.updateViewportContent()
+		}
+		return nil
 	case "h", "left":
 		return m.navigateLeft()
 	case "j", "down":
@@ -428,12 +980,23 @@ func (m *App) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 	case "T":
 		m.ShowTypeInfo = !m.ShowTypeInfo
 		return nil
+	case "C":
+		m.ShowCompletionItems = !m.ShowCompletionItems
+		return nil
+	case "I":
+		m.ShowDiagnostics = !m.ShowDiagnostics
+		return nil
 	case "+", "=":
-		// Increase current section height
-		return m.resizeSection(2)
+		// Grow the focused pane
+		return m.resizeSection(1)
 	case "-", "_":
-		// Decrease current section height
-		return m.resizeSection(-2)
+		// Shrink the focused pane
+		return m.resizeSection(-1)
+	case "|":
+		// Toggle split orientation between stacked and side-by-side
+		m.paneManager.ToggleOrientation()
+		m.initializeViewports()
+		return nil
 	}
 
 	return m.messageBridge.CheckMessages()
@@ -459,6 +1022,190 @@ func (m *App) handleSelectionKeys(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
+// handleCompletionKeys handles keys while the autocompletion popup is open.
+func (m *App) handleCompletionKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.Completion.Close()
+		return nil
+	case "enter":
+		m.applyCompletion()
+		return nil
+	case "up", "ctrl+p":
+		m.Completion.MoveSelection(-1)
+		return nil
+	case "down", "ctrl+n":
+		m.Completion.MoveSelection(1)
+		return nil
+	case "backspace":
+		m.Completion.Backspace()
+		return nil
+	}
+
+	if msg.Type == tea.KeyRunes {
+		m.Completion.TypePrefix(string(msg.Runes))
+	}
+	return nil
+}
+
+// handleTrustPromptKeys handles the accept/reject prompt shown when a
+// connecting client's key fingerprint doesn't match the one trusted on
+// first use.
+func (m *App) handleTrustPromptKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "y":
+		m.knownClients.Trust(trustLabel, m.pendingFingerprint)
+		if err := m.knownClients.Save(auth.DefaultPath()); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to persist known_clients: %v\n", err)
+		}
+		m.trustViolation = false
+		m.pendingFingerprint = ""
+	case "n", "esc":
+		m.trustViolation = false
+		m.pendingFingerprint = ""
+	}
+	return nil
+}
+
+// handleRefFinderKeys handles keys while the References fuzzy finder is open.
+func (m *App) handleRefFinderKeys(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.RefFinder.Close()
+		m.updateViewportContent()
+		return nil
+	case "enter":
+		m.jumpToCurrentMatch()
+		m.RefFinder.Close()
+		m.updateViewportContent()
+		return nil
+	case "up", "ctrl+p":
+		m.RefFinder.MoveSelection(-1)
+		m.updateViewportContent()
+		return nil
+	case "down", "ctrl+n":
+		m.RefFinder.MoveSelection(1)
+		m.updateViewportContent()
+		return nil
+	case "backspace":
+		m.RefFinder.Backspace()
+		m.updateViewportContent()
+		return nil
+	}
+
+	if msg.Type == tea.KeyRunes {
+		m.RefFinder.TypeQuery(string(msg.Runes))
+		m.updateViewportContent()
+	}
+	return nil
+}
+
+// jumpToCurrentMatch sends a jump message back over the socket asking
+// Neovim to move the cursor to the currently highlighted reference.
+func (m *App) jumpToCurrentMatch() {
+	ref := m.RefFinder.Current()
+	if ref == nil {
+		return
+	}
+
+	m.connMutex.RLock()
+	ch := m.clientChannel
+	m.connMutex.RUnlock()
+	if ch == nil {
+		return
+	}
+
+	msg, err := socket.CreateMessage("jump", ref)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingWriteTimeout)
+	defer cancel()
+	ch.WriteMessage(ctx, msg)
+}
+
+// Request writes a msgType message carrying data and blocks until Neovim
+// replies with a Message whose InReplyTo echoes it, ctx is done, or
+// socket.DefaultRequestTimeout elapses, whichever comes first. It lets
+// higher-level RPCs (e.g. "jump to reference N") be built on top of the
+// persistent connection without inventing a new ad-hoc message per call.
+func (m *App) Request(ctx context.Context, msgType string, data interface{}) (*socket.Message, error) {
+	m.connMutex.RLock()
+	ch := m.clientChannel
+	m.connMutex.RUnlock()
+	if ch == nil {
+		return nil, fmt.Errorf("no active connection")
+	}
+
+	msg, err := socket.CreateMessage(msgType, data)
+	if err != nil {
+		return nil, err
+	}
+
+	id, replies, cancel := m.pendingRequests.Register()
+	defer cancel()
+	msg.ID = id
+
+	if err := ch.WriteMessage(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	select {
+	case reply := <-replies:
+		return reply, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(socket.DefaultRequestTimeout):
+		return nil, fmt.Errorf("request %q timed out after %s", msgType, socket.DefaultRequestTimeout)
+	}
+}
+
+// requestCompletion issues textDocument/completion for the current
+// context position and opens the popup with the results.
+func (m *App) requestCompletion() tea.Cmd {
+	if m.Context == nil {
+		return nil
+	}
+
+	filetype := lsp.FiletypeFromPath(m.Context.File)
+	file, line, col := m.Context.File, m.Context.Line, m.Context.Col
+
+	return func() tea.Msg {
+		list, err := m.lspManager.Completion(filetype, file, line, col)
+		if err != nil {
+			return socket.ErrorMsg(fmt.Sprintf("completion request failed: %v", err))
+		}
+		return CompletionResultMsg{Items: list.Items}
+	}
+}
+
+// applyCompletion applies the selected item's additionalTextEdits (if any)
+// by forwarding them back to Neovim over the socket, then closes the popup.
+func (m *App) applyCompletion() {
+	item := m.Completion.Current()
+	m.Completion.Close()
+	if item == nil {
+		return
+	}
+
+	m.connMutex.RLock()
+	ch := m.clientChannel
+	m.connMutex.RUnlock()
+	if ch == nil {
+		return
+	}
+
+	msg, err := socket.CreateMessage("completion_apply", item)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingWriteTimeout)
+	defer cancel()
+	ch.WriteMessage(ctx, msg)
+}
+
 // Viewport navigation methods
 func (m *App) getCurrentViewport() *viewport.Model {
 	switch m.Focus {
@@ -470,6 +1217,10 @@ func (m *App) getCurrentViewport() *viewport.Model {
 		return &m.DefinitionViewport
 	case FocusTypeDefinition:
 		return &m.TypeInfoViewport
+	case FocusCompletionItems:
+		return &m.CompletionItemsViewport
+	case FocusDiagnostics:
+		return &m.DiagnosticsViewport
 	}
 	return &m.HoverViewport
 }
@@ -498,16 +1249,21 @@ func (m *App) halfPageDown() tea.Cmd {
 	return nil
 }
 
-func (m *App) resizeSection(delta int) tea.Cmd {
-	current := m.SectionHeights[m.Focus]
-	newHeight := current + delta
-	if newHeight < 3 {
-		newHeight = 3
+// resizeSection grows or shrinks the focused pane's weight relative to the
+// other visible panes, then re-derives viewport geometry from the result.
+func (m *App) resizeSection(direction int) tea.Cmd {
+	id := focusPaneID(m.Focus)
+	visible := make([]string, 0, len(paneIDs))
+	for _, area := range m.getVisibleAreas() {
+		visible = append(visible, focusPaneID(area))
 	}
-	if newHeight > m.Height-10 {
-		newHeight = m.Height - 10
+
+	if direction > 0 {
+		m.paneManager.Grow(id, visible)
+	} else {
+		m.paneManager.Shrink(id, visible)
 	}
-	m.SectionHeights[m.Focus] = newHeight
+
 	m.initializeViewports()
 	return nil
 }
@@ -567,6 +1323,12 @@ func (m *App) getVisibleAreas() []FocusArea {
 	if m.ShowTypeInfo && m.Context != nil && m.Context.TypeDefinition != nil {
 		areas = append(areas, FocusTypeDefinition)
 	}
+	if m.ShowCompletionItems && m.Context != nil && len(m.Context.CompletionItems) > 0 {
+		areas = append(areas, FocusCompletionItems)
+	}
+	if m.ShowDiagnostics && m.Context != nil && len(m.Context.Diagnostics) > 0 {
+		areas = append(areas, FocusDiagnostics)
+	}
 	return areas
 }
 
@@ -589,10 +1351,34 @@ func (m *App) toggleCurrentField() tea.Cmd {
 		m.ShowDefinition = !m.ShowDefinition
 	case FocusTypeDefinition:
 		m.ShowTypeInfo = !m.ShowTypeInfo
+	case FocusCompletionItems:
+		m.ShowCompletionItems = !m.ShowCompletionItems
+	case FocusDiagnostics:
+		m.ShowDiagnostics = !m.ShowDiagnostics
 	}
 	return nil
 }
 
+// cycleTheme hot-swaps the active palette to the next built-in theme,
+// regenerating Styles in place so every subsequent render picks it up.
+func (m *App) cycleTheme() {
+	names := styles.BuiltinThemeNames
+	next := names[0]
+	for i, name := range names {
+		if name == m.styles.Theme.Name {
+			next = names[(i+1)%len(names)]
+			break
+		}
+	}
+
+	theme, err := styles.LoadTheme(next)
+	if err != nil {
+		m.ErrorMsg = fmt.Sprintf("Failed to load theme %q: %v", next, err)
+		return
+	}
+	m.styles = styles.NewWithTheme(theme)
+}
+
 // View renders the application
 func (m *App) View() string {
 	if !m.Ready {
@@ -601,17 +1387,33 @@ func (m *App) View() string {
 
 	// Convert viewports to view.ViewportData
 	var viewData = &view.ViewData{
-		Context:        convertContextToSocket(m.Context),
-		ErrorMsg:       m.ErrorMsg,
-		Connected:      m.getConnectionState() == Connected,
-		LastUpdate:     m.LastUpdate,
-		Focus:          int(m.Focus),
-		ShowHover:      m.ShowHover,
-		ShowReferences: m.ShowReferences,
-		ShowDefinition: m.ShowDefinition,
-		ShowTypeInfo:   m.ShowTypeInfo,
-		MenuVisible:    false,
-		MenuSelection:  0,
+		Context:              convertContextToSocket(m.Context),
+		ErrorMsg:             m.ErrorMsg,
+		Connected:            m.getConnectionState() == Connected,
+		Reconnecting:         m.getConnectionState() == Reconnecting,
+		ReconnectAttempt:     m.reconnectAttempt,
+		ReconnectNextAttempt: m.reconnectNextAttempt,
+		LastUpdate:           m.LastUpdate,
+		Focus:                int(m.Focus),
+		ShowHover:            m.ShowHover,
+		ShowReferences:       m.ShowReferences,
+		ShowDefinition:       m.ShowDefinition,
+		ShowTypeInfo:         m.ShowTypeInfo,
+		ShowCompletionItems:  m.ShowCompletionItems,
+		ShowDiagnostics:      m.ShowDiagnostics,
+		MenuVisible:          false,
+		MenuSelection:        0,
+		Horizontal:           m.paneManager.Orientation == panes.Horizontal,
+
+		TrustViolation:   m.trustViolation,
+		TrustFingerprint: m.pendingFingerprint,
+
+		CompletionVisible:  m.Completion.Visible,
+		CompletionPrefix:   m.Completion.Prefix,
+		CompletionSelected: m.Completion.Selected,
+		CompletionItems:    completionItemViews(m.Completion.Filtered),
+
+		Sessions: m.sessionTabViews(),
 	}
 
 	// Pass actual viewports if ready
@@ -620,6 +1422,8 @@ func (m *App) View() string {
 		viewData.ReferencesViewport = &m.ReferencesViewport
 		viewData.DefinitionViewport = &m.DefinitionViewport
 		viewData.TypeInfoViewport = &m.TypeInfoViewport
+		viewData.CompletionItemsViewport = &m.CompletionItemsViewport
+		viewData.DiagnosticsViewport = &m.DiagnosticsViewport
 	}
 
 	return view.Render(m.Width, m.Height, viewData, m.styles)
@@ -627,6 +1431,38 @@ func (m *App) View() string {
 
 // Helper functions
 
+// sessionTabViews builds the header's session tab strip. It returns nil
+// when there's only one (or zero) sessions attached, since there's nothing
+// to disambiguate for a 1:1 TUI-to-Neovim setup.
+func (m *App) sessionTabViews() []view.SessionTabView {
+	ids := m.Sessions.IDs()
+	if len(ids) < 2 {
+		return nil
+	}
+
+	m.connMutex.RLock()
+	active := m.activeSession
+	m.connMutex.RUnlock()
+
+	tabs := make([]view.SessionTabView, len(ids))
+	for i, id := range ids {
+		name := fmt.Sprintf("nvim-%d", id)
+		if session := m.Sessions.Get(id); session != nil && session.Name != "" {
+			name = session.Name
+		}
+		tabs[i] = view.SessionTabView{Name: name, Active: id == active}
+	}
+	return tabs
+}
+
+func completionItemViews(items []lsp.CompletionItem) []view.CompletionItemView {
+	views := make([]view.CompletionItemView, len(items))
+	for i, item := range items {
+		views[i] = view.CompletionItemView{Label: item.Label, Detail: item.Detail}
+	}
+	return views
+}
+
 func convertSocketContextToModel(socketData *socket.ContextData) *Context {
 	if socketData == nil {
 		return nil
@@ -643,6 +1479,10 @@ func convertSocketContextToModel(socketData *socket.ContextData) *Context {
 		References:      socketData.References,
 		ReferencesMore:  socketData.ReferencesMore,
 		TypeDefinition:  socketData.TypeDefinition,
+		CompletionItems: socketData.CompletionItems,
+		Diagnostics:     socketData.Diagnostics,
+		SignatureHelp:   socketData.SignatureHelp,
+		CodeActions:     socketData.CodeActions,
 	}
 }
 
@@ -662,6 +1502,10 @@ func convertContextToSocket(context *Context) *socket.ContextData {
 		References:      context.References,
 		ReferencesMore:  context.ReferencesMore,
 		TypeDefinition:  context.TypeDefinition,
+		CompletionItems: context.CompletionItems,
+		Diagnostics:     context.Diagnostics,
+		SignatureHelp:   context.SignatureHelp,
+		CodeActions:     context.CodeActions,
 	}
 }
 
@@ -672,6 +1516,70 @@ func (m *App) setConnectionState(state ConnectionState) {
 	m.connectionState = state
 }
 
+// isActiveSession reports whether id is the session currently mirrored onto
+// m.Context/m.clientChannel, i.e. the one the UI is showing.
+func (m *App) isActiveSession(id uint32) bool {
+	m.connMutex.RLock()
+	defer m.connMutex.RUnlock()
+	return m.activeSession == id
+}
+
+// cycleSession moves the active session forward (delta > 0, bound to ]s)
+// or backward (delta < 0, bound to [s) through Sessions in insertion
+// order, hot-swapping m.Context so the visible pane reflects whatever the
+// newly active session last pushed.
+func (m *App) cycleSession(delta int) {
+	m.connMutex.RLock()
+	current := m.activeSession
+	m.connMutex.RUnlock()
+
+	var next uint32
+	if delta > 0 {
+		next = m.Sessions.Next(current)
+	} else {
+		next = m.Sessions.Prev(current)
+	}
+	if next == 0 || next == current {
+		return
+	}
+
+	session := m.Sessions.Get(next)
+	if session == nil {
+		return
+	}
+
+	m.connMutex.Lock()
+	m.activeSession = next
+	m.clientChannel = session.Channel
+	m.connMutex.Unlock()
+
+	m.applySessionSwitch(next)
+}
+
+// applySessionSwitch mirrors session id's Context onto m.Context and
+// refreshes the viewports it feeds, used both by cycleSession and by the
+// active session's own disconnect handing off to whichever session
+// replaces it.
+func (m *App) applySessionSwitch(id uint32) {
+	session := m.Sessions.Get(id)
+	if session == nil {
+		return
+	}
+
+	m.Context = session.Context
+	if session.Context != nil {
+		m.LastUpdate = session.LastUpdate
+	}
+	m.updateViewportContent()
+}
+
+// SessionSwitchedMsg is sent when the active session changes because its
+// previous occupant disconnected, so Update can hot-swap m.Context on the
+// main loop instead of the accept goroutine touching it directly.
+type SessionSwitchedMsg struct {
+	ID uint32
+}
+
 func (m *App) getConnectionState() ConnectionState {
 	m.connMutex.RLock()
 	defer m.connMutex.RUnlock()
@@ -694,12 +1602,31 @@ func (m *App) startSocketServer() tea.Cmd {
 
 		// Start accepting connections in background
 		go m.acceptConnections()
+		go m.reapIdleConnections()
 
 		// Signal that socket server is ready for connections
 		return ConnectionStateChangedMsg{State: Connecting}
 	}
 }
 
+// reapIdleConnections periodically sweeps Sessions for any that have been
+// Reconnecting longer than idleTimeout and tears them down, a backstop
+// independent of any individual session's own reconnectWindow timer (see
+// enterReconnecting) so a missed or delayed timer can't leave a stale
+// half-open connection around indefinitely.
+func (m *App) reapIdleConnections() {
+	ticker := time.NewTicker(m.idleTimeout / 2)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for _, id := range m.Sessions.CloseIdleConnections(m.idleTimeout) {
+			if session := m.Sessions.Get(id); session != nil {
+				m.finalizeDisconnect(session)
+			}
+		}
+	}
+}
+
 func (m *App) acceptConnections() {
 	for {
 		conn, err := m.socketListener.Accept()
@@ -717,22 +1644,157 @@ func (m *App) handleNewConnection(conn net.Conn) {
 	// Optimize socket for low-latency communication
 	m.optimizeSocket(conn)
 
-	m.connMutex.Lock()
+	// Authenticate before accepting any Message frames; this blocks on I/O,
+	// so it runs in its own goroutine rather than in the accept loop.
+	go m.authenticateAndServe(conn)
+}
+
+// authenticateAndServe runs the TOFU handshake on conn and, if it succeeds,
+// registers conn as a new session (the active one if no session is active
+// yet) and serves it. A failed
+// handshake (bad signature, or a fingerprint mismatch surfaced to the user
+// as a trust violation) closes the connection without ever routing its
+// frames into the message bridge.
+func (m *App) authenticateAndServe(conn net.Conn) {
+	// A single scanner spans both the handshake and the persistent message
+	// loop below so that any bytes the client pipelines right after its
+	// auth response aren't stranded in a scanner we then discard.
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	if !m.performTOFUHandshake(conn, scanner) {
+		conn.Close()
+		return
+	}
+
+	proto, err := m.negotiateProto(conn)
+	if err != nil {
+		conn.Close()
+		return
+	}
 
-	// Close existing connection if any
-	if m.clientConn != nil {
-		m.clientConn.Close()
+	msize, err := channel.NegotiateServer(conn, channel.MaxMSize)
+	if err != nil {
+		conn.Close()
+		return
 	}
 
-	m.clientConn = conn
+	var ch channel.Channel
+	if m.transport == TransportSHM {
+		// The shm handoff still uses conn for its own hello and the
+		// companion .fds exchange it drives off m.socketPath, so conn
+		// itself is the control connection rather than becoming the
+		// Channel's transport.
+		ch, err = negotiateShmChannel(conn, m.socketPath, msize)
+		if err != nil {
+			conn.Close()
+			return
+		}
+	} else {
+		// Wrap conn so the channel below can cancel an in-flight decode
+		// or encode once its deadline expires, not just the blocking
+		// syscall that precedes it.
+		dconn := socket.NewDeadlineConn(conn)
+		ch = channel.NewFrameChannel(dconn, proto, msize)
+	}
+
+	// Each accepted connection gets its own session, registered under an
+	// ID the TUI assigns here rather than one the client proposes -- the
+	// connection itself is already the unit of multiplexing, so there's
+	// nothing to negotiate beyond the friendly name a session_hello may
+	// supply later. The first session to attach becomes the active one
+	// automatically; later ones attach in the background until the user
+	// cycles to them with ]s/[s.
+	id := atomic.AddUint32(&m.sessionSeq, 1)
+	session, _ := m.Sessions.GetOrCreate(id, fmt.Sprintf("nvim-%d", id), ch)
+
+	m.connMutex.Lock()
+	if m.activeSession == 0 {
+		m.activeSession = id
+		m.clientConn = conn
+		m.clientChannel = ch
+	}
 	m.connectionState = Connected
 	m.connMutex.Unlock()
 
 	// Notify main loop of connection
 	m.messageBridge.SendMessage(ConnectionStateChangedMsg{State: Connected})
 
-	// Start handling this connection in a goroutine
-	go m.handlePersistentConnection(conn)
+	m.handlePersistentConnection(ch, session)
+}
+
+// negotiateProto reads the client's post-auth Hello frame and replies
+// with the proto it actually selected, falling back to JSON for anything
+// it doesn't recognize. The hello exchange always uses length-prefixed
+// frames encoded as JSON, independent of the proto it negotiates for the
+// Message frames that follow.
+func (m *App) negotiateProto(conn net.Conn) (frame.Proto, error) {
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	defer conn.SetDeadline(time.Time{})
+
+	hello, err := frame.ReadHello(conn)
+	if err != nil {
+		return frame.ProtoJSON, fmt.Errorf("failed to read hello: %w", err)
+	}
+
+	proto := frame.Negotiate(hello.Proto)
+
+	err = frame.WriteHello(conn, frame.Hello{Type: "hello", Proto: proto, Version: frame.Version})
+	if err != nil {
+		return frame.ProtoJSON, fmt.Errorf("failed to write hello: %w", err)
+	}
+
+	return proto, nil
+}
+
+// performTOFUHandshake sends an auth challenge and verifies the client's
+// signed response before any Message frames are trusted. On first
+// connection the fingerprint is recorded (trust-on-first-use); on a
+// mismatch it sends a TrustViolationMsg for the UI to surface instead of
+// silently accepting or dropping the connection.
+func (m *App) performTOFUHandshake(conn net.Conn, scanner *bufio.Scanner) bool {
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	defer conn.SetDeadline(time.Time{})
+
+	nonce, err := auth.NewNonce()
+	if err != nil {
+		return false
+	}
+
+	challenge, err := json.Marshal(auth.Challenge{Type: "auth_challenge", Nonce: hex.EncodeToString(nonce)})
+	if err != nil {
+		return false
+	}
+	if _, err := conn.Write(append(challenge, '\n')); err != nil {
+		return false
+	}
+
+	if !scanner.Scan() {
+		return false
+	}
+
+	var resp auth.Response
+	if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+		return false
+	}
+
+	_, fingerprint, err := auth.VerifyResponse(nonce, resp)
+	if err != nil {
+		return false
+	}
+
+	ok, isNew := m.knownClients.Check(trustLabel, fingerprint)
+	if !ok {
+		m.messageBridge.SendMessage(TrustViolationMsg{Fingerprint: fingerprint})
+		return false
+	}
+	if isNew {
+		if err := m.knownClients.Save(auth.DefaultPath()); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to persist known_clients: %v\n", err)
+		}
+	}
+
+	return true
 }
 
 func (m *App) optimizeSocket(conn net.Conn) {
@@ -751,60 +1813,102 @@ func (m *App) optimizeSocket(conn net.Conn) {
 	}
 }
 
-func (m *App) handlePersistentConnection(conn net.Conn) {
-	defer func() {
-		conn.Close()
-		m.connMutex.Lock()
-		if m.clientConn == conn {
-			m.clientConn = nil
-			m.connectionState = Disconnected
-		}
-		m.connMutex.Unlock()
-
-		m.messageBridge.SendMessage(ConnectionStateChangedMsg{State: Disconnected})
-	}()
+func (m *App) handlePersistentConnection(ch channel.Channel, session *Session) {
+	final, graceful := m.runConnectionLoop(ch, session)
+	ch.Close()
 
-	scanner := bufio.NewScanner(conn)
-	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	if graceful {
+		m.finalizeDisconnect(final)
+		return
+	}
 
-	conn.SetReadDeadline(time.Now().Add(m.connectionTimeout))
+	m.enterReconnecting(final)
+}
 
-	for scanner.Scan() {
-		conn.SetReadDeadline(time.Now().Add(m.connectionTimeout))
+// runConnectionLoop reads and dispatches messages from ch until the
+// connection ends, returning the session the connection ended up
+// belonging to (see the session_hello/Reclaim handling below) along with
+// whether the end was graceful: an explicit "disconnect" or session_bye
+// message. Anything else -- EOF, a read timeout, or a lower-level
+// transport error -- reports false, so the caller treats it as a drop the
+// client might still redial from rather than a final goodbye.
+func (m *App) runConnectionLoop(ch channel.Channel, session *Session) (*Session, bool) {
+	for {
+		var msg socket.Message
+		ctx, cancel := context.WithTimeout(context.Background(), m.connectionTimeout)
+		err := ch.ReadMessage(ctx, &msg)
+		cancel()
+		if err != nil {
+			switch err {
+			case io.EOF:
+			case socket.ErrDeadlineExceeded, context.DeadlineExceeded:
+				m.messageBridge.SendMessage(socket.ErrorMsg("deadline exceeded"))
+			case channel.ErrFrameTooLarge:
+				m.messageBridge.SendMessage(socket.ErrorMsg("frame exceeds negotiated size"))
+				continue
+			default:
+				m.messageBridge.SendMessage(socket.ErrorMsg(fmt.Sprintf("Connection error: %v", err)))
+			}
+			return session, false
+		}
 
-		line := scanner.Text()
-		if line == "" {
-			continue
+		if m.recorder != nil {
+			if err := m.recorder.Record(msg); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to record session: %v\n", err)
+			}
 		}
 
-		var msg socket.Message
-		if err := json.Unmarshal([]byte(line), &msg); err != nil {
-			m.messageBridge.SendMessage(socket.ErrorMsg(fmt.Sprintf("Failed to parse message: %v", err)))
+		// A reply to one of our own Request calls is delivered straight to
+		// the caller waiting on it instead of flowing through the normal
+		// type-based dispatch below.
+		if m.pendingRequests.Resolve(&msg) {
 			continue
 		}
 
 		switch msg.Type {
 		case "context_update":
 			if contextData, ok := msg.ExtractContextData(); ok {
-				m.messageBridge.SendMessage(socket.ContextUpdateMsg{Data: contextData})
+				m.Sessions.Update(session.ID, convertSocketContextToModel(contextData))
+				if m.isActiveSession(session.ID) {
+					m.messageBridge.SendMessage(socket.ContextUpdateMsg{Data: contextData})
+				}
 			} else {
 				m.messageBridge.SendMessage(socket.ErrorMsg("Failed to extract context data from message"))
 			}
 
 		case "cursor_pos":
 			if contextData, ok := msg.ExtractContextData(); ok {
-				m.messageBridge.SendMessage(socket.ContextUpdateMsg{Data: contextData})
+				m.Sessions.Update(session.ID, convertSocketContextToModel(contextData))
+				if m.isActiveSession(session.ID) {
+					m.messageBridge.SendMessage(socket.ContextUpdateMsg{Data: contextData})
+				}
 			}
 
 		case "ping":
 			if pingData, ok := msg.ExtractPingData(); ok {
-				m.handlePing(conn, pingData.Timestamp)
+				m.handlePing(ch, pingData.Timestamp, msg.ID)
 			} else {
-				m.handlePing(conn, msg.Timestamp)
+				m.handlePing(ch, msg.Timestamp, msg.ID)
 			}
 
-		case "disconnect":
-			return
+		case socket.MessageTypeSessionHello:
+			if helloData, ok := msg.ExtractSessionHelloData(); ok && helloData.Name != "" {
+				// A name that matches a session still waiting out its
+				// reconnectWindow means this connection is that client
+				// redialing, not a new one: fold it back into the
+				// existing session so its Context history survives
+				// rather than starting over under a fresh ID.
+				if reclaimed, ok := m.Sessions.Reclaim(helloData.Name, ch); ok && reclaimed.ID != session.ID {
+					m.Sessions.Remove(session.ID)
+					m.promoteReclaimedSession(session.ID, reclaimed)
+					session = reclaimed
+				} else {
+					m.Sessions.Rename(session.ID, helloData.Name)
+				}
+			}
+
+		case "disconnect", socket.MessageTypeSessionBye:
+			return session, true
 
 		case "error":
 			if errorData, ok := msg.ExtractErrorData(); ok {
@@ -817,25 +1921,119 @@ func (m *App) handlePersistentConnection(conn net.Conn) {
 			m.messageBridge.SendMessage(socket.ErrorMsg(fmt.Sprintf("Unknown message type: %s", msg.Type)))
 		}
 	}
+}
+
+// promoteReclaimedSession hands the active-session slot from a freshly
+// accepted connection's now-discarded placeholder ID over to the session
+// it turned out to be reclaiming, so a redial that happened to be the
+// first connection accepted after a drop doesn't leave the UI pointing at
+// an ID that no longer exists.
+func (m *App) promoteReclaimedSession(placeholderID uint32, reclaimed *Session) {
+	m.connMutex.Lock()
+	defer m.connMutex.Unlock()
+	if m.activeSession == placeholderID {
+		m.activeSession = reclaimed.ID
+		m.clientChannel = reclaimed.Channel
+	}
+}
+
+// enterReconnecting marks session as having dropped its connection
+// without a graceful goodbye and, if it's the one currently shown, flips
+// the UI into the Reconnecting state and starts watchReconnect counting
+// down reconnectWindow.
+func (m *App) enterReconnecting(session *Session) {
+	wasActive := m.isActiveSession(session.ID)
+	m.Sessions.MarkReconnecting(session.ID)
+
+	if wasActive {
+		m.setConnectionState(Reconnecting)
+		m.messageBridge.SendMessage(ConnectionStateChangedMsg{State: Reconnecting})
+	}
+
+	go m.watchReconnect(session.ID, wasActive)
+}
+
+// watchReconnect ticks a jittered exponential backoff (see
+// reconnectBackoff) for up to reconnectWindow, surfacing each tick as a
+// ReconnectStatusMsg when session is the one on screen. If nothing
+// reclaims the session before the window closes, it's torn down for good;
+// if session_hello's Reclaim beats the clock, MarkReconnecting's flag is
+// already cleared and this is a no-op.
+func (m *App) watchReconnect(id uint32, active bool) {
+	deadline := time.Now().Add(m.reconnectWindow)
+
+	for attempt := 1; ; attempt++ {
+		session := m.Sessions.Get(id)
+		if session == nil || !session.Reconnecting {
+			return
+		}
+
+		next := time.Now().Add(reconnectBackoff(attempt))
+		if !next.Before(deadline) {
+			break
+		}
+		if active {
+			m.messageBridge.SendMessage(ReconnectStatusMsg{Attempt: attempt, NextAttempt: next})
+		}
+		time.Sleep(time.Until(next))
+	}
 
-	if err := scanner.Err(); err != nil {
-		m.messageBridge.SendMessage(socket.ErrorMsg(fmt.Sprintf("Connection error: %v", err)))
+	if session := m.Sessions.Get(id); session != nil && session.Reconnecting {
+		m.finalizeDisconnect(session)
 	}
 }
 
-func (m *App) handlePing(conn net.Conn, clientTimestamp int64) {
-	pong := map[string]interface{}{
-		"type":             "pong",
-		"timestamp":        time.Now().UnixMilli(),
-		"client_timestamp": clientTimestamp,
+// finalizeDisconnect removes session for good, reassigning the active
+// session (and notifying the UI) if session was the one on screen. It's
+// the single place that tears a session down, called whether the cause
+// was an explicit disconnect/session_bye, watchReconnect's window closing,
+// or the reapIdleConnections backstop sweep.
+func (m *App) finalizeDisconnect(session *Session) {
+	m.connMutex.Lock()
+	if m.activeSession == session.ID {
+		next := m.Sessions.Next(session.ID)
+		m.activeSession = next
+		if next != 0 {
+			if nextSession := m.Sessions.Get(next); nextSession != nil {
+				m.clientChannel = nextSession.Channel
+			}
+		} else {
+			m.clientChannel = nil
+			m.clientConn = nil
+		}
+	}
+	nextActive := m.activeSession
+	m.connMutex.Unlock()
+
+	m.Sessions.Remove(session.ID)
+
+	state := Connected
+	if m.Sessions.Len() == 0 {
+		state = Disconnected
+		m.setConnectionState(Disconnected)
 	}
 
-	data, err := json.Marshal(pong)
+	if nextActive != 0 && nextActive != session.ID {
+		m.messageBridge.SendMessage(SessionSwitchedMsg{ID: nextActive})
+	}
+	m.messageBridge.SendMessage(ConnectionStateChangedMsg{State: state})
+}
+
+func (m *App) handlePing(ch channel.Channel, clientTimestamp int64, requestID string) {
+	pong, err := socket.CreatePongMessage(clientTimestamp, requestID)
 	if err != nil {
 		return
 	}
 
-	conn.Write(append(data, '\n'))
+	ctx, cancel := context.WithTimeout(context.Background(), pingWriteTimeout)
+	defer cancel()
+	if err := ch.WriteMessage(ctx, pong); err != nil {
+		if err == socket.ErrDeadlineExceeded || err == context.DeadlineExceeded {
+			m.messageBridge.SendMessage(socket.ErrorMsg("deadline exceeded"))
+		}
+		return
+	}
+
 	m.messageBridge.SendMessage(HeartbeatMsg{Timestamp: time.Now().UnixMilli()})
 }
 
@@ -848,5 +2046,6 @@ func (m *App) GetConnectionStatus() map[string]interface{} {
 		"connected":   m.connectionState == Connected,
 		"socket_path": m.socketPath,
 		"last_update": m.LastUpdate,
+		"sessions":    m.Sessions.Len(),
 	}
 }
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"strings"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/lsp"
+)
+
+// CompletionState tracks the autocompletion popup: the full item list
+// returned by the language server, the user's in-progress filter prefix,
+// and which item is currently highlighted.
+type CompletionState struct {
+	Visible  bool
+	Items    []lsp.CompletionItem
+	Filtered []lsp.CompletionItem
+	Prefix   string
+	Selected int
+}
+
+// Open shows the popup with a fresh item list and clears any prior filter.
+func (c *CompletionState) Open(items []lsp.CompletionItem) {
+	c.Visible = true
+	c.Items = items
+	c.Prefix = ""
+	c.Selected = 0
+	c.Filtered = items
+}
+
+// Close hides the popup and drops its item list.
+func (c *CompletionState) Close() {
+	c.Visible = false
+	c.Items = nil
+	c.Filtered = nil
+	c.Prefix = ""
+	c.Selected = 0
+}
+
+// TypePrefix appends a rune to the filter prefix and refilters.
+func (c *CompletionState) TypePrefix(r string) {
+	c.Prefix += r
+	c.refilter()
+}
+
+// Backspace removes the last rune from the filter prefix and refilters.
+func (c *CompletionState) Backspace() {
+	if c.Prefix == "" {
+		return
+	}
+	runes := []rune(c.Prefix)
+	c.Prefix = string(runes[:len(runes)-1])
+	c.refilter()
+}
+
+// refilter narrows Items down to those whose label contains Prefix,
+// case-insensitively. It's a simple substring match rather than a full
+// fuzzy scorer; the References panel's `/` finder uses sahilm/fuzzy for
+// ranked matching instead.
+func (c *CompletionState) refilter() {
+	if c.Prefix == "" {
+		c.Filtered = c.Items
+		c.Selected = 0
+		return
+	}
+
+	needle := strings.ToLower(c.Prefix)
+	var matched []lsp.CompletionItem
+	for _, item := range c.Items {
+		if strings.Contains(strings.ToLower(item.Label), needle) {
+			matched = append(matched, item)
+		}
+	}
+	c.Filtered = matched
+	if c.Selected >= len(c.Filtered) {
+		c.Selected = 0
+	}
+}
+
+// MoveSelection shifts the highlighted item by delta, clamped to bounds.
+func (c *CompletionState) MoveSelection(delta int) {
+	if len(c.Filtered) == 0 {
+		return
+	}
+	c.Selected += delta
+	if c.Selected < 0 {
+		c.Selected = 0
+	}
+	if c.Selected >= len(c.Filtered) {
+		c.Selected = len(c.Filtered) - 1
+	}
+}
+
+// Current returns the highlighted item, or nil if the popup is empty.
+func (c *CompletionState) Current() *lsp.CompletionItem {
+	if c.Selected < 0 || c.Selected >= len(c.Filtered) {
+		return nil
+	}
+	return &c.Filtered[c.Selected]
+}
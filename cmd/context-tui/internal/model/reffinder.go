@@ -0,0 +1,103 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/sahilm/fuzzy"
+	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+)
+
+// ReferenceFinder turns the References panel into a navigable fuzzy finder
+// for symbols with hundreds of references, rather than a static preview
+// truncated at 10 items.
+type ReferenceFinder struct {
+	Active   bool
+	Query    string
+	all      []socket.LocationInfo
+	Matches  []fuzzy.Match
+	Selected int
+}
+
+// referenceLabel builds the "path:line" string fuzzy.Find matches against
+// for a single reference. LocationInfo carries no source text, so no
+// preview of the referencing line is included.
+func referenceLabel(ref socket.LocationInfo) string {
+	return fmt.Sprintf("%s:%d", ref.File, ref.Line)
+}
+
+// Open enters finder mode against the full (untruncated) reference list.
+func (f *ReferenceFinder) Open(refs []socket.LocationInfo) {
+	f.Active = true
+	f.Query = ""
+	f.all = refs
+	f.Selected = 0
+	f.refilter()
+}
+
+// Close exits finder mode.
+func (f *ReferenceFinder) Close() {
+	f.Active = false
+	f.Query = ""
+	f.all = nil
+	f.Matches = nil
+	f.Selected = 0
+}
+
+// TypeQuery appends to the query and refilters.
+func (f *ReferenceFinder) TypeQuery(r string) {
+	f.Query += r
+	f.refilter()
+}
+
+// Backspace removes the last rune from the query and refilters.
+func (f *ReferenceFinder) Backspace() {
+	if f.Query == "" {
+		return
+	}
+	runes := []rune(f.Query)
+	f.Query = string(runes[:len(runes)-1])
+	f.refilter()
+}
+
+func (f *ReferenceFinder) refilter() {
+	labels := make([]string, len(f.all))
+	for i, ref := range f.all {
+		labels[i] = referenceLabel(ref)
+	}
+
+	if f.Query == "" {
+		f.Matches = make([]fuzzy.Match, len(labels))
+		for i, label := range labels {
+			f.Matches[i] = fuzzy.Match{Str: label, Index: i}
+		}
+	} else {
+		f.Matches = fuzzy.Find(f.Query, labels)
+	}
+
+	if f.Selected >= len(f.Matches) {
+		f.Selected = 0
+	}
+}
+
+// MoveSelection shifts the highlighted match by delta, clamped to bounds.
+func (f *ReferenceFinder) MoveSelection(delta int) {
+	if len(f.Matches) == 0 {
+		return
+	}
+	f.Selected += delta
+	if f.Selected < 0 {
+		f.Selected = 0
+	}
+	if f.Selected >= len(f.Matches) {
+		f.Selected = len(f.Matches) - 1
+	}
+}
+
+// Current returns the reference behind the highlighted match.
+func (f *ReferenceFinder) Current() *socket.LocationInfo {
+	if f.Selected < 0 || f.Selected >= len(f.Matches) {
+		return nil
+	}
+	ref := f.all[f.Matches[f.Selected].Index]
+	return &ref
+}
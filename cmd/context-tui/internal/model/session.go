@@ -0,0 +1,221 @@
+package model
+
+import (
+	"sync"
+	"time"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket/channel"
+)
+
+// Session is one connected Neovim instance's slice of multiplexed state:
+// its transport, the last Context it pushed, and when that last happened.
+// SessionRegistry is the single source of truth for which sessions exist;
+// App.Context/App.clientChannel are just a read/write mirror of whichever
+// session is currently active.
+type Session struct {
+	ID         uint32
+	Name       string
+	Channel    channel.Channel
+	Context    *Context
+	LastUpdate time.Time
+
+	// Reconnecting is set when this session's connection dropped without
+	// an explicit disconnect/session_bye (an EOF or read error instead),
+	// so a client that redials can Reclaim its identity and history
+	// rather than starting over as a brand new session. DisconnectedAt
+	// marks when that happened, the basis for CloseIdleConnections'
+	// staleness check.
+	Reconnecting   bool
+	DisconnectedAt time.Time
+}
+
+// SessionRegistry tracks every concurrently connected Neovim instance,
+// keyed by session ID, with insertion order preserved separately so ]s/[s
+// cycle predictably instead of following Go's randomized map iteration.
+type SessionRegistry struct {
+	mu       sync.Mutex
+	sessions map[uint32]*Session
+	order    []uint32
+}
+
+// NewSessionRegistry creates an empty registry.
+func NewSessionRegistry() *SessionRegistry {
+	return &SessionRegistry{sessions: make(map[uint32]*Session)}
+}
+
+// GetOrCreate returns the session for id, registering a new one (with ch as
+// its transport and name as its initial label) if id hasn't been seen
+// before. The second return value reports whether a new session was
+// created.
+func (r *SessionRegistry) GetOrCreate(id uint32, name string, ch channel.Channel) (*Session, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if s, ok := r.sessions[id]; ok {
+		return s, false
+	}
+
+	s := &Session{ID: id, Name: name, Channel: ch}
+	r.sessions[id] = s
+	r.order = append(r.order, id)
+	return s, true
+}
+
+// Get returns the session for id, or nil if it isn't registered.
+func (r *SessionRegistry) Get(id uint32) *Session {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessions[id]
+}
+
+// Rename updates the friendly name a session announced in its
+// session_hello, which may arrive after the session was auto-registered
+// under a placeholder name.
+func (r *SessionRegistry) Rename(id uint32, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.sessions[id]; ok {
+		s.Name = name
+	}
+}
+
+// Update stores ctx as the session's current Context and bumps LastUpdate.
+func (r *SessionRegistry) Update(id uint32, ctx *Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.sessions[id]; ok {
+		s.Context = ctx
+		s.LastUpdate = time.Now()
+	}
+}
+
+// MarkReconnecting flags id's session as having dropped its connection
+// without a graceful disconnect/session_bye, starting the clock that
+// Reclaim or CloseIdleConnections will race against.
+func (r *SessionRegistry) MarkReconnecting(id uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.sessions[id]; ok {
+		s.Reconnecting = true
+		s.DisconnectedAt = time.Now()
+		s.Channel = nil
+	}
+}
+
+// Reclaim reattaches ch to the Reconnecting session named name, clearing
+// its Reconnecting flag so a client that redials after a drop resumes its
+// prior identity and Context history instead of starting a fresh session.
+// It reports false if no session by that name is currently waiting.
+func (r *SessionRegistry) Reclaim(name string, ch channel.Channel) (*Session, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range r.order {
+		s := r.sessions[id]
+		if s.Reconnecting && s.Name == name {
+			s.Channel = ch
+			s.Reconnecting = false
+			s.DisconnectedAt = time.Time{}
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// CloseIdleConnections reports every tracked session that has been sitting
+// in Reconnecting for longer than idleTimeout without a client redialing
+// to Reclaim it, mirroring net/http.Transport's method of the same name.
+// It's a read-only sweep: removing the session and reassigning the active
+// one if needed is the caller's job (see App.finalizeDisconnect), so that
+// bookkeeping stays in one place regardless of whether a session's own
+// reconnectWindow timer or this backstop sweep is what finally gives up.
+func (r *SessionRegistry) CloseIdleConnections(idleTimeout time.Duration) []uint32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	var stale []uint32
+	for _, id := range r.order {
+		if s := r.sessions[id]; s.Reconnecting && now.Sub(s.DisconnectedAt) >= idleTimeout {
+			stale = append(stale, id)
+		}
+	}
+	return stale
+}
+
+// Remove drops id from the registry, e.g. on session_bye or disconnect.
+func (r *SessionRegistry) Remove(id uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sessions[id]; !ok {
+		return
+	}
+	delete(r.sessions, id)
+
+	for i, existing := range r.order {
+		if existing == id {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// IDs returns every registered session ID in insertion order.
+func (r *SessionRegistry) IDs() []uint32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]uint32, len(r.order))
+	copy(ids, r.order)
+	return ids
+}
+
+// Len returns the number of currently registered sessions.
+func (r *SessionRegistry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.order)
+}
+
+// Next returns the session ID that follows current in insertion order,
+// wrapping around. If current isn't registered, the first session is
+// returned. It returns 0 if the registry is empty.
+func (r *SessionRegistry) Next(current uint32) uint32 {
+	return r.step(current, 1)
+}
+
+// Prev returns the session ID that precedes current in insertion order,
+// wrapping around. It returns 0 if the registry is empty.
+func (r *SessionRegistry) Prev(current uint32) uint32 {
+	return r.step(current, -1)
+}
+
+func (r *SessionRegistry) step(current uint32, delta int) uint32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := len(r.order)
+	if n == 0 {
+		return 0
+	}
+
+	idx := -1
+	for i, id := range r.order {
+		if id == current {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		// current isn't registered: Next starts at the first session,
+		// Prev at the last.
+		if delta > 0 {
+			return r.order[0]
+		}
+		return r.order[n-1]
+	}
+
+	idx = (idx + delta + n) % n
+	return r.order[idx]
+}
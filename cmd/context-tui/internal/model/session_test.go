@@ -0,0 +1,140 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionRegistryGetOrCreateRegistersOnce(t *testing.T) {
+	r := NewSessionRegistry()
+
+	s1, created := r.GetOrCreate(1, "nvim-a", nil)
+	if !created {
+		t.Fatal("expected the first GetOrCreate for a given id to create a session")
+	}
+	if s1.Name != "nvim-a" {
+		t.Errorf("Name mismatch: got %q, want %q", s1.Name, "nvim-a")
+	}
+
+	s2, created := r.GetOrCreate(1, "nvim-b", nil)
+	if created {
+		t.Fatal("expected a second GetOrCreate for the same id to reuse the existing session")
+	}
+	if s2 != s1 {
+		t.Error("expected GetOrCreate to return the same *Session pointer for an existing id")
+	}
+	if s2.Name != "nvim-a" {
+		t.Errorf("existing session's Name should be unaffected by the second GetOrCreate, got %q", s2.Name)
+	}
+}
+
+func TestSessionRegistryRenameUpdatesExistingSession(t *testing.T) {
+	r := NewSessionRegistry()
+	r.GetOrCreate(1, "", nil)
+
+	r.Rename(1, "nvim-renamed")
+
+	if got := r.Get(1).Name; got != "nvim-renamed" {
+		t.Errorf("Name mismatch after Rename: got %q, want %q", got, "nvim-renamed")
+	}
+}
+
+func TestSessionRegistryRemove(t *testing.T) {
+	r := NewSessionRegistry()
+	r.GetOrCreate(1, "a", nil)
+	r.GetOrCreate(2, "b", nil)
+
+	r.Remove(1)
+
+	if r.Get(1) != nil {
+		t.Error("expected Get to return nil for a removed session")
+	}
+	if r.Len() != 1 {
+		t.Errorf("Len mismatch after Remove: got %d, want 1", r.Len())
+	}
+}
+
+func TestSessionRegistryNextPrevCycleInInsertionOrder(t *testing.T) {
+	r := NewSessionRegistry()
+	r.GetOrCreate(1, "a", nil)
+	r.GetOrCreate(2, "b", nil)
+	r.GetOrCreate(3, "c", nil)
+
+	if got := r.Next(1); got != 2 {
+		t.Errorf("Next(1) = %d, want 2", got)
+	}
+	if got := r.Next(3); got != 1 {
+		t.Errorf("Next(3) should wrap around to 1, got %d", got)
+	}
+	if got := r.Prev(1); got != 3 {
+		t.Errorf("Prev(1) should wrap around to 3, got %d", got)
+	}
+}
+
+func TestSessionRegistryNextPrevEmpty(t *testing.T) {
+	r := NewSessionRegistry()
+
+	if got := r.Next(0); got != 0 {
+		t.Errorf("Next on an empty registry = %d, want 0", got)
+	}
+	if got := r.Prev(0); got != 0 {
+		t.Errorf("Prev on an empty registry = %d, want 0", got)
+	}
+}
+
+func TestSessionRegistryReclaimReattachesByName(t *testing.T) {
+	r := NewSessionRegistry()
+	r.GetOrCreate(1, "nvim-a", nil)
+	r.MarkReconnecting(1)
+
+	reclaimed, ok := r.Reclaim("nvim-a", nil)
+	if !ok {
+		t.Fatal("expected Reclaim to find the Reconnecting session by name")
+	}
+	if reclaimed.ID != 1 {
+		t.Errorf("Reclaim returned session %d, want 1", reclaimed.ID)
+	}
+	if reclaimed.Reconnecting {
+		t.Error("expected Reclaim to clear the Reconnecting flag")
+	}
+
+	if _, ok := r.Reclaim("nvim-a", nil); ok {
+		t.Error("expected a second Reclaim for the same name to find nothing once reattached")
+	}
+}
+
+func TestSessionRegistryCloseIdleConnectionsReportsOnlyStaleSessions(t *testing.T) {
+	r := NewSessionRegistry()
+	r.GetOrCreate(1, "fresh", nil)
+	r.GetOrCreate(2, "stale", nil)
+	r.MarkReconnecting(1)
+	r.MarkReconnecting(2)
+	r.Get(2).DisconnectedAt = time.Now().Add(-time.Minute)
+
+	stale := r.CloseIdleConnections(time.Second)
+	if len(stale) != 1 || stale[0] != 2 {
+		t.Errorf("CloseIdleConnections = %v, want [2]", stale)
+	}
+
+	if r.Get(2) == nil {
+		t.Error("CloseIdleConnections should only report stale sessions, not remove them")
+	}
+}
+
+func TestSessionRegistryIDsReturnsInsertionOrder(t *testing.T) {
+	r := NewSessionRegistry()
+	r.GetOrCreate(5, "a", nil)
+	r.GetOrCreate(2, "b", nil)
+	r.GetOrCreate(9, "c", nil)
+
+	ids := r.IDs()
+	want := []uint32{5, 2, 9}
+	if len(ids) != len(want) {
+		t.Fatalf("IDs length mismatch: got %d, want %d", len(ids), len(want))
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("IDs[%d] = %d, want %d", i, ids[i], want[i])
+		}
+	}
+}
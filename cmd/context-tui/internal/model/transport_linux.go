@@ -0,0 +1,17 @@
+package model
+
+import (
+	"net"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket/channel"
+	"nvim-hoverfloat/cmd/context-tui/internal/socket/shm"
+)
+
+// negotiateShmChannel hands conn off to shm.NegotiateServer, building the
+// shared-memory ring-buffer Channel for this connection. It's split into
+// its own GOOS-suffixed file because shm itself is Linux-only (mmap,
+// eventfd, epoll); see transport_other.go for the stub every other
+// platform gets instead.
+func negotiateShmChannel(conn net.Conn, socketPath string, msize int) (channel.Channel, error) {
+	return shm.NegotiateServer(conn, socketPath, msize)
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+
+package model
+
+import (
+	"fmt"
+	"net"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket/channel"
+)
+
+// negotiateShmChannel is the non-Linux stand-in for transport_linux.go's
+// real negotiation: the shm transport depends on mmap/eventfd/epoll, so
+// --transport=shm simply isn't available off Linux.
+func negotiateShmChannel(conn net.Conn, socketPath string, msize int) (channel.Channel, error) {
+	return nil, fmt.Errorf("shm transport is only available on linux")
+}
@@ -0,0 +1,205 @@
+// Package panes models the TUI's content area as a set of resizable,
+// proportionally-weighted panes so renderers get exact geometry instead of
+// each section guessing a shrinking "remainingHeight".
+package panes
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Orientation controls whether panes are stacked vertically (the current
+// default, one section per row) or split side by side.
+type Orientation int
+
+const (
+	Vertical Orientation = iota
+	Horizontal
+)
+
+// Rect is the exact geometry a renderer should draw a pane's content into.
+type Rect struct {
+	X, Y, Width, Height int
+}
+
+// Pane is one resizable section, identified by a stable ID (matching
+// model.FocusArea) so layout persists across sessions regardless of
+// which sections happen to be visible right now.
+type Pane struct {
+	ID     string  `json:"id"`
+	Weight float64 `json:"weight"`
+}
+
+const (
+	minWeight = 0.05
+	step      = 0.05
+)
+
+// Manager owns the pane list and split orientation for the content area.
+type Manager struct {
+	Orientation Orientation `json:"orientation"`
+	Panes       []Pane      `json:"panes"`
+}
+
+// NewManager builds a Manager with equal weights across the given pane IDs.
+func NewManager(ids ...string) *Manager {
+	weight := 1.0 / float64(len(ids))
+	panes := make([]Pane, len(ids))
+	for i, id := range ids {
+		panes[i] = Pane{ID: id, Weight: weight}
+	}
+	return &Manager{Orientation: Vertical, Panes: panes}
+}
+
+// ToggleOrientation flips between Vertical and Horizontal splits.
+func (m *Manager) ToggleOrientation() {
+	if m.Orientation == Vertical {
+		m.Orientation = Horizontal
+	} else {
+		m.Orientation = Vertical
+	}
+}
+
+// Grow increases the named pane's weight by one step, taking it in equal
+// shares from every other visible pane. A pane clamped at minWeight gives
+// up less than its share, so the weights' sum can then drift above 1;
+// Layout divides by the visible total, so geometry stays proportional.
+func (m *Manager) Grow(id string, visible []string) {
+	m.adjust(id, visible, step)
+}
+
+// Shrink decreases the named pane's weight by one step.
+func (m *Manager) Shrink(id string, visible []string) {
+	m.adjust(id, visible, -step)
+}
+
+func (m *Manager) adjust(id string, visible []string, delta float64) {
+	target := m.find(id)
+	if target == nil || len(visible) < 2 {
+		return
+	}
+
+	newWeight := target.Weight + delta
+	if newWeight < minWeight {
+		newWeight = minWeight
+	}
+	actualDelta := newWeight - target.Weight
+
+	others := make([]*Pane, 0, len(visible)-1)
+	for _, vid := range visible {
+		if vid == id {
+			continue
+		}
+		if p := m.find(vid); p != nil {
+			others = append(others, p)
+		}
+	}
+	if len(others) == 0 {
+		return
+	}
+
+	perOther := actualDelta / float64(len(others))
+	for _, p := range others {
+		p.Weight -= perOther
+		if p.Weight < minWeight {
+			p.Weight = minWeight
+		}
+	}
+	target.Weight = newWeight
+}
+
+func (m *Manager) find(id string) *Pane {
+	for i := range m.Panes {
+		if m.Panes[i].ID == id {
+			return &m.Panes[i]
+		}
+	}
+	return nil
+}
+
+// Layout computes exact pixel rects for the given visible pane IDs, in the
+// order supplied, splitting width/height proportionally to their weights
+// along the current orientation.
+func (m *Manager) Layout(visible []string, width, height int) map[string]Rect {
+	result := make(map[string]Rect, len(visible))
+	if len(visible) == 0 {
+		return result
+	}
+
+	var total float64
+	for _, id := range visible {
+		if p := m.find(id); p != nil {
+			total += p.Weight
+		}
+	}
+	if total <= 0 {
+		total = 1
+	}
+
+	offset := 0
+	for i, id := range visible {
+		p := m.find(id)
+		weight := 1.0 / float64(len(visible))
+		if p != nil {
+			weight = p.Weight
+		}
+
+		switch m.Orientation {
+		case Horizontal:
+			w := int(float64(width) * weight / total)
+			if i == len(visible)-1 {
+				w = width - offset
+			}
+			result[id] = Rect{X: offset, Y: 0, Width: w, Height: height}
+			offset += w
+		default:
+			h := int(float64(height) * weight / total)
+			if i == len(visible)-1 {
+				h = height - offset
+			}
+			result[id] = Rect{X: 0, Y: offset, Width: width, Height: h}
+			offset += h
+		}
+	}
+
+	return result
+}
+
+// Save persists the layout to path as JSON.
+func (m *Manager) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Load restores a previously saved layout from path, returning an error if
+// the file doesn't exist or can't be parsed so the caller can fall back to
+// NewManager's equal-weight defaults.
+func Load(path string) (*Manager, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var m Manager
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// DefaultLayoutPath is where per-user layout state is persisted.
+func DefaultLayoutPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "nvim-hoverfloat", "layout.json")
+}
@@ -0,0 +1,150 @@
+package panes
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestNewManagerEqualWeights(t *testing.T) {
+	m := NewManager("hover", "references", "diagnostics")
+
+	if len(m.Panes) != 3 {
+		t.Fatalf("Expected 3 panes, got %d", len(m.Panes))
+	}
+	for _, p := range m.Panes {
+		if p.Weight != 1.0/3.0 {
+			t.Errorf("Pane %q weight = %v, want %v", p.ID, p.Weight, 1.0/3.0)
+		}
+	}
+}
+
+func TestToggleOrientation(t *testing.T) {
+	m := NewManager("a", "b")
+	if m.Orientation != Vertical {
+		t.Fatalf("Expected default orientation to be Vertical")
+	}
+
+	m.ToggleOrientation()
+	if m.Orientation != Horizontal {
+		t.Errorf("Expected Horizontal after one toggle")
+	}
+
+	m.ToggleOrientation()
+	if m.Orientation != Vertical {
+		t.Errorf("Expected Vertical after two toggles")
+	}
+}
+
+func TestGrowShrinkRedistributeAcrossVisible(t *testing.T) {
+	m := NewManager("a", "b", "c")
+	visible := []string{"a", "b", "c"}
+
+	m.Grow("a", visible)
+
+	if got := m.find("a").Weight; got <= 1.0/3.0 {
+		t.Errorf("Grow: a's weight = %v, want > %v", got, 1.0/3.0)
+	}
+	if got := m.find("b").Weight; got >= 1.0/3.0 {
+		t.Errorf("Grow: b's weight = %v, want < %v", got, 1.0/3.0)
+	}
+
+	m.Shrink("a", visible)
+	if got := m.find("a").Weight; got > 1.0/3.0+1e-9 {
+		t.Errorf("Shrink did not undo the prior Grow: a's weight = %v", got)
+	}
+}
+
+func TestShrinkClampsToMinWeight(t *testing.T) {
+	m := NewManager("a", "b")
+	visible := []string{"a", "b"}
+
+	for i := 0; i < 100; i++ {
+		m.Shrink("a", visible)
+	}
+
+	if got := m.find("a").Weight; got < minWeight {
+		t.Errorf("Shrink drove weight below minWeight: got %v, want >= %v", got, minWeight)
+	}
+}
+
+func TestAdjustNoopWithFewerThanTwoVisible(t *testing.T) {
+	m := NewManager("a", "b")
+	before := m.find("a").Weight
+
+	m.Grow("a", []string{"a"})
+
+	if got := m.find("a").Weight; got != before {
+		t.Errorf("Grow with a single visible pane should be a no-op: got %v, want %v", got, before)
+	}
+}
+
+func TestLayoutVerticalSplitsHeightByWeight(t *testing.T) {
+	m := NewManager("a", "b")
+
+	rects := m.Layout([]string{"a", "b"}, 100, 200)
+
+	if rects["a"].Height+rects["b"].Height != 200 {
+		t.Errorf("Layout heights don't sum to total: %d + %d != 200", rects["a"].Height, rects["b"].Height)
+	}
+	if rects["a"].Width != 100 || rects["b"].Width != 100 {
+		t.Errorf("Vertical layout should give every pane the full width")
+	}
+}
+
+func TestLayoutHorizontalSplitsWidthByWeight(t *testing.T) {
+	m := NewManager("a", "b")
+	m.ToggleOrientation()
+
+	rects := m.Layout([]string{"a", "b"}, 100, 200)
+
+	if rects["a"].Width+rects["b"].Width != 100 {
+		t.Errorf("Layout widths don't sum to total: %d + %d != 100", rects["a"].Width, rects["b"].Width)
+	}
+	if rects["a"].Height != 200 || rects["b"].Height != 200 {
+		t.Errorf("Horizontal layout should give every pane the full height")
+	}
+}
+
+func TestLayoutEmptyVisibleReturnsEmptyMap(t *testing.T) {
+	m := NewManager("a", "b")
+
+	rects := m.Layout(nil, 100, 200)
+
+	if len(rects) != 0 {
+		t.Errorf("Expected no rects for an empty visible list, got %d", len(rects))
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	m := NewManager("a", "b")
+	m.Grow("a", []string{"a", "b"})
+	path := filepath.Join(t.TempDir(), "layout.json")
+
+	if err := m.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if loaded.Orientation != m.Orientation {
+		t.Errorf("Orientation mismatch: got %v, want %v", loaded.Orientation, m.Orientation)
+	}
+	if len(loaded.Panes) != len(m.Panes) {
+		t.Fatalf("Pane count mismatch: got %d, want %d", len(loaded.Panes), len(m.Panes))
+	}
+	for i, p := range loaded.Panes {
+		if p != m.Panes[i] {
+			t.Errorf("Pane %d mismatch: got %+v, want %+v", i, p, m.Panes[i])
+		}
+	}
+}
+
+func TestLoadMissingFileReturnsError(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "does-not-exist.json"))
+	if err == nil {
+		t.Error("Expected Load to return an error for a missing file")
+	}
+}
@@ -0,0 +1,127 @@
+package recorder
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+)
+
+// LoadFrames reads and decodes every length-prefixed frame from a
+// recording file produced by Recorder.
+func LoadFrames(path string) ([]Frame, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	var frames []Frame
+	for len(data) > 0 {
+		if len(data) < 4 {
+			return nil, fmt.Errorf("truncated recording: incomplete frame length")
+		}
+		n := binary.BigEndian.Uint32(data[:4])
+		data = data[4:]
+
+		if uint32(len(data)) < n {
+			return nil, fmt.Errorf("truncated recording: incomplete frame body")
+		}
+
+		var frame Frame
+		if err := json.Unmarshal(data[:n], &frame); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal frame: %w", err)
+		}
+		frames = append(frames, frame)
+		data = data[n:]
+	}
+
+	return frames, nil
+}
+
+// Player replays a recorded session's frames at their original inter-
+// message delays, scaled by Speed, optionally looping forever.
+type Player struct {
+	frames []Frame
+	speed  float64
+	loop   bool
+
+	mu     sync.Mutex
+	paused bool
+	step   chan struct{}
+}
+
+// NewPlayer loads path and prepares it for playback. speed scales the
+// delay between frames (2.0 plays twice as fast, 0.5 half as fast); a
+// non-positive speed is treated as 1.0.
+func NewPlayer(path string, speed float64, loop bool) (*Player, error) {
+	frames, err := LoadFrames(path)
+	if err != nil {
+		return nil, err
+	}
+	if speed <= 0 {
+		speed = 1.0
+	}
+	return &Player{frames: frames, speed: speed, loop: loop, step: make(chan struct{}, 1)}, nil
+}
+
+// TogglePause pauses playback before the next frame, or resumes it.
+func (p *Player) TogglePause() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.paused = !p.paused
+}
+
+// Step advances playback by exactly one frame while paused; it has no
+// effect otherwise.
+func (p *Player) Step() {
+	if !p.isPaused() {
+		return
+	}
+	select {
+	case p.step <- struct{}{}:
+	default:
+	}
+}
+
+func (p *Player) isPaused() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused
+}
+
+// Play blocks, invoking emit for each recorded frame at its original
+// inter-message delay (scaled by Speed), looping if configured. Call it
+// from its own goroutine.
+func (p *Player) Play(emit func(socket.Message)) {
+	for {
+		var prevOffset int64
+		for _, frame := range p.frames {
+			delay := time.Duration(float64(frame.OffsetMS-prevOffset)/p.speed) * time.Millisecond
+			prevOffset = frame.OffsetMS
+			if delay > 0 {
+				time.Sleep(delay)
+			}
+
+			// While paused, wait for a Step (which lets exactly this frame
+			// through) or for TogglePause to resume playback.
+		waitStep:
+			for p.isPaused() {
+				select {
+				case <-p.step:
+					break waitStep
+				case <-time.After(50 * time.Millisecond):
+				}
+			}
+
+			emit(frame.Message)
+		}
+
+		if !p.loop {
+			return
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Package recorder persists and replays sequences of socket.Message
+// traffic as length-prefixed JSON frames, so a session can be captured
+// with `--record` and later replayed with `--replay` for reproducible bug
+// reports, UI regression testing against real LSP traffic, and demos that
+// don't need a live Neovim instance.
+package recorder
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+)
+
+// Frame is one recorded message, timestamped relative to the start of the
+// recording rather than wall-clock time so a replay's pacing is
+// independent of when it's played back.
+type Frame struct {
+	OffsetMS int64          `json:"offset_ms"`
+	Message  socket.Message `json:"message"`
+}
+
+// Recorder appends inbound messages to a file as length-prefixed JSON
+// frames. Length-prefixing (rather than plain newline-delimited JSONL)
+// keeps the format unambiguous even though Message payloads are never
+// expected to contain raw newlines.
+type Recorder struct {
+	mu    sync.Mutex
+	f     *os.File
+	start time.Time
+}
+
+// NewRecorder creates (or truncates) the recording file at path.
+func NewRecorder(path string) (*Recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording file: %w", err)
+	}
+	return &Recorder{f: f}, nil
+}
+
+// Record appends msg to the recording with its offset from the first
+// recorded message.
+func (r *Recorder) Record(msg socket.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.start.IsZero() {
+		r.start = time.Now()
+	}
+
+	frame := Frame{
+		OffsetMS: time.Since(r.start).Milliseconds(),
+		Message:  msg,
+	}
+
+	data, err := json.Marshal(frame)
+	if err != nil {
+		return fmt.Errorf("failed to marshal frame: %w", err)
+	}
+
+	var lenPrefix [4]byte
+	binary.BigEndian.PutUint32(lenPrefix[:], uint32(len(data)))
+
+	if _, err := r.f.Write(lenPrefix[:]); err != nil {
+		return fmt.Errorf("failed to write frame length: %w", err)
+	}
+	if _, err := r.f.Write(data); err != nil {
+		return fmt.Errorf("failed to write frame: %w", err)
+	}
+	return nil
+}
+
+// Close flushes and closes the underlying recording file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.f.Close()
+}
@@ -0,0 +1,110 @@
+package recorder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+)
+
+func TestRecordLoadFramesRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.rec")
+
+	rec, err := NewRecorder(path)
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+
+	msg1 := socket.Message{Type: "context_update", Timestamp: 1}
+	msg2 := socket.Message{Type: "ping", Timestamp: 2}
+
+	if err := rec.Record(msg1); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := rec.Record(msg2); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	frames, err := LoadFrames(path)
+	if err != nil {
+		t.Fatalf("LoadFrames failed: %v", err)
+	}
+
+	if len(frames) != 2 {
+		t.Fatalf("Expected 2 frames, got %d", len(frames))
+	}
+	if frames[0].Message.Type != msg1.Type || frames[1].Message.Type != msg2.Type {
+		t.Errorf("Frame message types out of order: got [%s %s], want [%s %s]",
+			frames[0].Message.Type, frames[1].Message.Type, msg1.Type, msg2.Type)
+	}
+	if frames[0].OffsetMS > frames[1].OffsetMS {
+		t.Errorf("Expected non-decreasing offsets, got %d then %d", frames[0].OffsetMS, frames[1].OffsetMS)
+	}
+}
+
+func TestLoadFramesTruncatedFileErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "truncated.rec")
+	if err := os.WriteFile(path, []byte{0, 0, 0, 10}, 0o600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	if _, err := LoadFrames(path); err == nil {
+		t.Error("Expected LoadFrames to reject a frame length with no matching body")
+	}
+}
+
+func TestNewPlayerDefaultsNonPositiveSpeedToOne(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.rec")
+	rec, err := NewRecorder(path)
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+	rec.Record(socket.Message{Type: "ping"})
+	rec.Close()
+
+	player, err := NewPlayer(path, 0, false)
+	if err != nil {
+		t.Fatalf("NewPlayer failed: %v", err)
+	}
+	if player.speed != 1.0 {
+		t.Errorf("speed = %v, want 1.0 for a non-positive input", player.speed)
+	}
+}
+
+func TestPlayEmitsFramesInOrderWithoutLooping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.rec")
+	rec, err := NewRecorder(path)
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+	rec.Record(socket.Message{Type: "a"})
+	rec.Record(socket.Message{Type: "b"})
+	rec.Close()
+
+	player, err := NewPlayer(path, 100, false)
+	if err != nil {
+		t.Fatalf("NewPlayer failed: %v", err)
+	}
+
+	var got []string
+	done := make(chan struct{})
+	go func() {
+		player.Play(func(msg socket.Message) { got = append(got, msg.Type) })
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Play did not return without looping")
+	}
+
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("Play emitted %v, want [a b]", got)
+	}
+}
@@ -0,0 +1,215 @@
+package sinks
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+)
+
+// RotatingFileSink appends one JSON object per line to Filename, rotating
+// it once it grows past MaxSizeMB: the current file is closed, gzipped
+// alongside it with a timestamp suffix, and a fresh file is opened in its
+// place. Backups beyond MaxBackups, or older than MaxAgeDays, are deleted
+// as part of rotation.
+type RotatingFileSink struct {
+	Filename   string
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+// NewRotatingFileSink opens (or creates) Filename for appending and
+// returns a sink ready to rotate it per the given limits. A MaxSizeMB of
+// 0 disables size-based rotation; MaxBackups and MaxAgeDays of 0 disable
+// their respective retention checks.
+func NewRotatingFileSink(filename string, maxSizeMB, maxBackups, maxAgeDays int) (*RotatingFileSink, error) {
+	s := &RotatingFileSink{
+		Filename:   filename,
+		MaxSizeMB:  maxSizeMB,
+		MaxBackups: maxBackups,
+		MaxAgeDays: maxAgeDays,
+	}
+	if err := s.openCurrent(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *RotatingFileSink) openCurrent() error {
+	f, err := os.OpenFile(s.Filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open sink file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat sink file: %w", err)
+	}
+	s.f = f
+	s.size = info.Size()
+	return nil
+}
+
+func (s *RotatingFileSink) WriteContext(ctx *socket.ContextData) error {
+	if ctx == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to marshal context: %w", err)
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.MaxSizeMB > 0 && s.size+int64(len(data)) > int64(s.MaxSizeMB)*1024*1024 {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.f.Write(data)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write to sink file: %w", err)
+	}
+	return nil
+}
+
+// backupTimeLayout is both the suffix rotate appends to a backup's
+// filename and the layout backupTime parses it back with.
+const backupTimeLayout = "20060102T150405.000"
+
+// rotate closes the current file, gzips it under a timestamped backup
+// name, opens a fresh file in its place, and prunes backups beyond
+// MaxBackups/MaxAgeDays. Callers must hold s.mu. openCurrent runs even if
+// the gzip/remove step fails, so a transient rotation failure doesn't
+// leave the sink writing to an already-closed file.
+func (s *RotatingFileSink) rotate() error {
+	if err := s.f.Close(); err != nil {
+		return fmt.Errorf("failed to close sink file for rotation: %w", err)
+	}
+
+	backup := fmt.Sprintf("%s.%s.gz", s.Filename, time.Now().UTC().Format(backupTimeLayout))
+	rotateErr := gzipToFile(s.Filename, backup)
+	if rotateErr == nil {
+		rotateErr = os.Remove(s.Filename)
+	}
+
+	if err := s.openCurrent(); err != nil {
+		return fmt.Errorf("failed to reopen sink file after rotation: %w", err)
+	}
+	if rotateErr != nil {
+		return fmt.Errorf("failed to rotate sink file: %w", rotateErr)
+	}
+
+	return s.pruneBackups()
+}
+
+func gzipToFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open sink file for rotation: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("failed to create rotated backup: %w", err)
+	}
+	gz := gzip.NewWriter(out)
+
+	if _, err := io.Copy(gz, in); err != nil {
+		gz.Close()
+		out.Close()
+		return fmt.Errorf("failed to gzip rotated backup: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to finalize gzip backup: %w", err)
+	}
+	return out.Close()
+}
+
+// backups returns the sink's rotated backup files, oldest first.
+func (s *RotatingFileSink) backups() ([]string, error) {
+	matches, err := filepath.Glob(s.Filename + ".*.gz")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rotated backups: %w", err)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+func (s *RotatingFileSink) pruneBackups() error {
+	matches, err := s.backups()
+	if err != nil {
+		return err
+	}
+
+	if s.MaxAgeDays > 0 {
+		cutoff := time.Now().Add(-time.Duration(s.MaxAgeDays) * 24 * time.Hour)
+		kept := matches[:0]
+		for _, m := range matches {
+			if backupTime(m).Before(cutoff) {
+				if err := os.Remove(m); err != nil {
+					return fmt.Errorf("failed to remove aged-out backup: %w", err)
+				}
+				continue
+			}
+			kept = append(kept, m)
+		}
+		matches = kept
+	}
+
+	if s.MaxBackups > 0 && len(matches) > s.MaxBackups {
+		for _, m := range matches[:len(matches)-s.MaxBackups] {
+			if err := os.Remove(m); err != nil {
+				return fmt.Errorf("failed to remove excess backup: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// backupTime parses the timestamp rotate embedded in a backup's filename,
+// returning the zero time if it doesn't match the expected format (so a
+// malformed or foreign file is treated as infinitely old rather than
+// erroring out the whole prune pass). It takes the trailing
+// len(backupTimeLayout) characters before ".gz" rather than splitting on
+// the last ".", since the layout itself contains a "." before the
+// milliseconds.
+func backupTime(name string) time.Time {
+	base := strings.TrimSuffix(filepath.Base(name), ".gz")
+	if len(base) < len(backupTimeLayout) {
+		return time.Time{}
+	}
+	ts := base[len(base)-len(backupTimeLayout):]
+	t, err := time.Parse(backupTimeLayout, ts)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func (s *RotatingFileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
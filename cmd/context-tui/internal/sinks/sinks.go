@@ -0,0 +1,74 @@
+// Package sinks fans out accepted context updates to pluggable
+// destinations -- a human-readable console stream, a flat JSONL trace,
+// or a size/age-rotated file -- so a session's LSP context history can be
+// recorded for debugging flaky servers or consumed by external tooling
+// (fzf pickers, ML-driven ranking) without touching the socket protocol.
+package sinks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+)
+
+// Sink receives every accepted context update in addition to it being
+// rendered into the TUI's viewports. Implementations must be safe to call
+// from the single Bubble Tea update goroutine; none of the current ones
+// need their own locking since App only ever calls them serially.
+type Sink interface {
+	WriteContext(ctx *socket.ContextData) error
+	Close() error
+}
+
+// ConsoleSink writes a one-line human-readable summary of each update to
+// an io.Writer, typically os.Stderr so it doesn't collide with the TUI's
+// own output.
+type ConsoleSink struct {
+	w io.Writer
+}
+
+// NewConsoleSink returns a ConsoleSink writing to w.
+func NewConsoleSink(w io.Writer) *ConsoleSink {
+	return &ConsoleSink{w: w}
+}
+
+func (s *ConsoleSink) WriteContext(ctx *socket.ContextData) error {
+	if ctx == nil {
+		return nil
+	}
+	_, err := fmt.Fprintf(s.w, "[%s] %s:%d:%d hover=%t refs=%d diagnostics=%d\n",
+		time.UnixMilli(ctx.Timestamp).Format("15:04:05.000"),
+		ctx.File, ctx.Line, ctx.Col,
+		ctx.HasHover(), ctx.GetTotalReferences(), len(ctx.Diagnostics))
+	return err
+}
+
+func (s *ConsoleSink) Close() error { return nil }
+
+// JSONLSink appends each update to w as one JSON object per line.
+type JSONLSink struct {
+	w   io.Writer
+	enc *json.Encoder
+}
+
+// NewJSONLSink returns a JSONLSink writing to w.
+func NewJSONLSink(w io.Writer) *JSONLSink {
+	return &JSONLSink{w: w, enc: json.NewEncoder(w)}
+}
+
+func (s *JSONLSink) WriteContext(ctx *socket.ContextData) error {
+	if ctx == nil {
+		return nil
+	}
+	return s.enc.Encode(ctx)
+}
+
+func (s *JSONLSink) Close() error {
+	if closer, ok := s.w.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
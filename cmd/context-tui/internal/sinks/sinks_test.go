@@ -0,0 +1,182 @@
+package sinks
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+)
+
+func TestJSONLSinkWritesOneObjectPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONLSink(&buf)
+
+	for i := 0; i < 3; i++ {
+		if err := sink.WriteContext(&socket.ContextData{File: "main.go", Line: i}); err != nil {
+			t.Fatalf("WriteContext failed: %v", err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3", len(lines))
+	}
+	for i, line := range lines {
+		var got socket.ContextData
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d: invalid JSON: %v", i, err)
+		}
+		if got.Line != i {
+			t.Errorf("line %d: Line = %d, want %d", i, got.Line, i)
+		}
+	}
+}
+
+func TestConsoleSinkNilContextIsNoop(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewConsoleSink(&buf)
+
+	if err := sink.WriteContext(nil); err != nil {
+		t.Fatalf("WriteContext(nil) failed: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("WriteContext(nil) wrote %q, want nothing", buf.String())
+	}
+}
+
+func TestParseSpecsBuildsExpectedKinds(t *testing.T) {
+	dir := t.TempDir()
+	jsonlPath := filepath.Join(dir, "ctx.jsonl")
+
+	sinks, err := ParseSpecs("console,jsonl:" + jsonlPath)
+	if err != nil {
+		t.Fatalf("ParseSpecs failed: %v", err)
+	}
+	if len(sinks) != 2 {
+		t.Fatalf("got %d sinks, want 2", len(sinks))
+	}
+	if _, ok := sinks[0].(*ConsoleSink); !ok {
+		t.Errorf("sinks[0] = %T, want *ConsoleSink", sinks[0])
+	}
+	if _, ok := sinks[1].(*JSONLSink); !ok {
+		t.Errorf("sinks[1] = %T, want *JSONLSink", sinks[1])
+	}
+	for _, s := range sinks {
+		s.Close()
+	}
+}
+
+func TestParseSpecsRejectsUnknownKind(t *testing.T) {
+	if _, err := ParseSpecs("carrier-pigeon"); err == nil {
+		t.Error("ParseSpecs(\"carrier-pigeon\") succeeded, want error")
+	}
+}
+
+func TestRotatingFileSinkRotatesOnSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ctx.jsonl")
+
+	sink, err := NewRotatingFileSink(path, 1, 0, 0)
+	if err != nil {
+		t.Fatalf("NewRotatingFileSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	// Fake the current file already being past the 1MB threshold rather
+	// than writing a real megabyte of context updates.
+	sink.mu.Lock()
+	sink.size = 2 * 1024 * 1024
+	sink.mu.Unlock()
+
+	if err := sink.WriteContext(&socket.ContextData{File: "main.go"}); err != nil {
+		t.Fatalf("WriteContext failed: %v", err)
+	}
+
+	backups, err := sink.backups()
+	if err != nil {
+		t.Fatalf("backups failed: %v", err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("got %d backups, want 1", len(backups))
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("current file missing after rotation: %v", err)
+	}
+}
+
+func TestRotatingFileSinkPrunesExcessBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ctx.jsonl")
+
+	sink, err := NewRotatingFileSink(path, 1, 2, 0)
+	if err != nil {
+		t.Fatalf("NewRotatingFileSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	for i := 0; i < 4; i++ {
+		sink.mu.Lock()
+		sink.size = 100 * 1024 * 1024
+		sink.mu.Unlock()
+		if err := sink.WriteContext(&socket.ContextData{File: "main.go", Line: i}); err != nil {
+			t.Fatalf("WriteContext %d failed: %v", i, err)
+		}
+	}
+
+	backups, err := sink.backups()
+	if err != nil {
+		t.Fatalf("backups failed: %v", err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("got %d backups, want 2 (MaxBackups)", len(backups))
+	}
+}
+
+func TestRotatingFileSinkPrunesAgedOutBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ctx.jsonl")
+
+	old := time.Now().Add(-48 * time.Hour)
+	oldBackup := path + "." + old.Format(backupTimeLayout) + ".gz"
+	if err := os.WriteFile(oldBackup, []byte{}, 0o644); err != nil {
+		t.Fatalf("failed to seed aged-out backup: %v", err)
+	}
+
+	sink, err := NewRotatingFileSink(path, 1, 0, 1)
+	if err != nil {
+		t.Fatalf("NewRotatingFileSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	sink.mu.Lock()
+	sink.size = 100 * 1024 * 1024
+	sink.mu.Unlock()
+	if err := sink.WriteContext(&socket.ContextData{File: "main.go"}); err != nil {
+		t.Fatalf("WriteContext failed: %v", err)
+	}
+
+	if _, err := os.Stat(oldBackup); !os.IsNotExist(err) {
+		t.Errorf("aged-out backup %q still present after rotation, want pruned", oldBackup)
+	}
+}
+
+func TestBackupTimeRoundTripsRotateFilename(t *testing.T) {
+	now := time.Date(2026, 7, 28, 9, 10, 37, 345_000_000, time.UTC)
+	name := "ctx.jsonl." + now.Format(backupTimeLayout) + ".gz"
+
+	got := backupTime(name)
+	if !got.Equal(now) {
+		t.Errorf("backupTime(%q) = %v, want %v", name, got, now)
+	}
+}
+
+func TestBackupTimeUnparsableNameIsZero(t *testing.T) {
+	if got := backupTime("ctx.jsonl.not-a-timestamp.gz"); !got.IsZero() {
+		t.Errorf("backupTime on malformed name = %v, want zero time", got)
+	}
+}
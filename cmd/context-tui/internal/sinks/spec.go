@@ -0,0 +1,103 @@
+package sinks
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ParseSpecs builds a Sink for each comma-separated spec in specs, in
+// order, for wiring up the --sink flag (e.g.
+// "jsonl:/tmp/ctx.jsonl,console,rotating:/var/log/ctx.jsonl?max_size_mb=10&max_backups=5&max_age_days=7").
+// Each spec is "kind" or "kind:arg"; kind is one of "console", "jsonl",
+// or "rotating". jsonl's arg is a file path written in append mode;
+// rotating's arg is a file path optionally followed by a "?"-separated
+// query string of max_size_mb, max_backups, and max_age_days.
+func ParseSpecs(specs string) ([]Sink, error) {
+	var out []Sink
+	for _, spec := range strings.Split(specs, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		sink, err := parseSpec(spec)
+		if err != nil {
+			for _, opened := range out {
+				opened.Close()
+			}
+			return nil, err
+		}
+		out = append(out, sink)
+	}
+	return out, nil
+}
+
+func parseSpec(spec string) (Sink, error) {
+	kind, arg, _ := strings.Cut(spec, ":")
+
+	switch kind {
+	case "console":
+		return NewConsoleSink(os.Stderr), nil
+
+	case "jsonl":
+		if arg == "" {
+			return nil, fmt.Errorf("sink %q: jsonl requires a file path", spec)
+		}
+		f, err := os.OpenFile(arg, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("sink %q: %w", spec, err)
+		}
+		return NewJSONLSink(f), nil
+
+	case "rotating":
+		if arg == "" {
+			return nil, fmt.Errorf("sink %q: rotating requires a file path", spec)
+		}
+		path, params, _ := strings.Cut(arg, "?")
+		maxSizeMB, maxBackups, maxAgeDays, err := parseRotatingParams(params)
+		if err != nil {
+			return nil, fmt.Errorf("sink %q: %w", spec, err)
+		}
+		sink, err := NewRotatingFileSink(path, maxSizeMB, maxBackups, maxAgeDays)
+		if err != nil {
+			return nil, fmt.Errorf("sink %q: %w", spec, err)
+		}
+		return sink, nil
+
+	default:
+		return nil, fmt.Errorf("sink %q: unknown kind %q (want console, jsonl, or rotating)", spec, kind)
+	}
+}
+
+func parseRotatingParams(params string) (maxSizeMB, maxBackups, maxAgeDays int, err error) {
+	maxSizeMB = 100
+	maxBackups = 7
+	maxAgeDays = 28
+
+	if params == "" {
+		return maxSizeMB, maxBackups, maxAgeDays, nil
+	}
+
+	for _, kv := range strings.Split(params, "&") {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			return 0, 0, 0, fmt.Errorf("malformed param %q", kv)
+		}
+		n, convErr := strconv.Atoi(value)
+		if convErr != nil {
+			return 0, 0, 0, fmt.Errorf("param %q: %w", kv, convErr)
+		}
+		switch key {
+		case "max_size_mb":
+			maxSizeMB = n
+		case "max_backups":
+			maxBackups = n
+		case "max_age_days":
+			maxAgeDays = n
+		default:
+			return 0, 0, 0, fmt.Errorf("unknown param %q", key)
+		}
+	}
+	return maxSizeMB, maxBackups, maxAgeDays, nil
+}
@@ -0,0 +1,169 @@
+// Package channel implements a framed, MSize-negotiated message transport
+// over a net.Conn, modeled loosely after 9P's framed T/Rmessage transport.
+// Every frame is the same 4-byte big-endian length header socket/frame
+// already uses, followed by that many bytes of payload encoded with
+// whichever frame.Proto the connection negotiated. Unlike frame's
+// ReadFrame/WriteFrame, a Channel owns a reused read buffer sized to its
+// negotiated MSize so steady-state traffic on a long-lived connection
+// doesn't allocate a fresh buffer per message, and it enforces MSize
+// itself -- rejecting an oversize frame with ErrFrameTooLarge rather than
+// closing the connection -- instead of relying on frame.MaxFrameSize.
+package channel
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+	"nvim-hoverfloat/cmd/context-tui/internal/socket/frame"
+)
+
+// DefaultMSize is the size a Channel's read buffer starts at before any
+// negotiation takes place.
+const DefaultMSize = 1 << 20 // 1 MiB
+
+// MaxMSize is the hard ceiling NegotiateServer clamps a client's proposal
+// to, regardless of what the client asks for.
+const MaxMSize = 16 << 20 // 16 MiB, matching frame.MaxFrameSize
+
+// ErrFrameTooLarge is returned by ReadMessage when a frame's declared
+// length exceeds the channel's negotiated MSize. The oversize payload is
+// still drained from the stream so the connection stays in sync and can
+// keep serving subsequent, correctly sized frames.
+var ErrFrameTooLarge = errors.New("channel: frame exceeds negotiated MSize")
+
+// Channel is a framed message transport. ReadMessage and WriteMessage are
+// each single-threaded from the caller's point of view -- WriteMessage
+// additionally serializes concurrent callers through an internal mutex --
+// and both respect ctx's deadline and cancellation on top of whatever
+// deadline is already set on the underlying connection.
+type Channel interface {
+	ReadMessage(ctx context.Context, msg *socket.Message) error
+	WriteMessage(ctx context.Context, msg *socket.Message) error
+	MSize() int
+	SetMSize(n int)
+	Close() error
+}
+
+// FrameChannel is the net.Conn-backed Channel implementation used by the
+// TUI's socket server.
+type FrameChannel struct {
+	conn  *socket.DeadlineConn
+	proto frame.Proto
+
+	msize int
+	buf   []byte
+
+	writeMu sync.Mutex
+}
+
+// NewFrameChannel wraps conn as a Channel that encodes messages with proto
+// and treats msize as its negotiated frame size ceiling.
+func NewFrameChannel(conn *socket.DeadlineConn, proto frame.Proto, msize int) *FrameChannel {
+	return &FrameChannel{
+		conn:  conn,
+		proto: proto,
+		msize: msize,
+		buf:   make([]byte, msize),
+	}
+}
+
+// MSize returns the channel's current negotiated frame size ceiling.
+func (c *FrameChannel) MSize() int { return c.msize }
+
+// SetMSize resizes the channel's read buffer. It isn't safe to call
+// concurrently with ReadMessage.
+func (c *FrameChannel) SetMSize(n int) {
+	c.msize = n
+	c.buf = make([]byte, n)
+}
+
+// Close closes the underlying connection.
+func (c *FrameChannel) Close() error { return c.conn.Close() }
+
+// ReadMessage reads and decodes the next frame into msg. The read races
+// against ctx the same way the connection's own deadline already races a
+// pathological decode against socket.DeadlineConn's ReadDone, so a
+// caller's context cancellation takes effect even mid-read.
+func (c *FrameChannel) ReadMessage(ctx context.Context, msg *socket.Message) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		c.conn.SetReadDeadline(deadline)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- c.readFrame(msg) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.conn.ReadDone():
+		return socket.ErrDeadlineExceeded
+	}
+}
+
+func (c *FrameChannel) readFrame(msg *socket.Message) error {
+	var lenPrefix [4]byte
+	if _, err := io.ReadFull(c.conn, lenPrefix[:]); err != nil {
+		return err
+	}
+	size := int(binary.BigEndian.Uint32(lenPrefix[:]))
+
+	if size > c.msize {
+		if _, err := io.CopyN(io.Discard, c.conn, int64(size)); err != nil {
+			return fmt.Errorf("channel: draining oversize frame: %w", err)
+		}
+		return ErrFrameTooLarge
+	}
+
+	if cap(c.buf) < size {
+		c.buf = make([]byte, size)
+	}
+	payload := c.buf[:size]
+	if _, err := io.ReadFull(c.conn, payload); err != nil {
+		return fmt.Errorf("channel: reading frame payload: %w", err)
+	}
+
+	return frame.DecodeMessage(c.proto, payload, msg)
+}
+
+// WriteMessage encodes msg and writes it as a single frame. Concurrent
+// callers are serialized through writeMu, which is held until the
+// spawned write goroutine actually finishes writing to conn -- not just
+// until WriteMessage returns -- so a caller that gives up on a ctx
+// timeout or WriteDone can't let a second WriteMessage start a new write
+// while the abandoned one is still in flight and interleave raw bytes on
+// the wire.
+func (c *FrameChannel) WriteMessage(ctx context.Context, msg *socket.Message) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		c.conn.SetWriteDeadline(deadline)
+	}
+
+	c.writeMu.Lock()
+
+	done := make(chan error, 1)
+	go func() {
+		defer c.writeMu.Unlock()
+
+		payload, err := frame.EncodeMessage(c.proto, msg)
+		if err != nil {
+			done <- err
+			return
+		}
+		done <- frame.WriteFrame(c.conn, payload)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.conn.WriteDone():
+		return socket.ErrDeadlineExceeded
+	}
+}
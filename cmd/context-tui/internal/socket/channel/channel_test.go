@@ -0,0 +1,219 @@
+package channel
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+	"nvim-hoverfloat/cmd/context-tui/internal/socket/frame"
+)
+
+func pipeChannels(t *testing.T, msize int) (*FrameChannel, *FrameChannel) {
+	t.Helper()
+
+	a, b := net.Pipe()
+	ca := NewFrameChannel(socket.NewDeadlineConn(a), frame.ProtoJSON, msize)
+	cb := NewFrameChannel(socket.NewDeadlineConn(b), frame.ProtoJSON, msize)
+	t.Cleanup(func() {
+		ca.Close()
+		cb.Close()
+	})
+	return ca, cb
+}
+
+func TestFrameChannelRoundTrip(t *testing.T) {
+	ca, cb := pipeChannels(t, DefaultMSize)
+
+	sent, err := socket.CreateMessage("context_update", &socket.ContextData{File: "main.go", Line: 10})
+	if err != nil {
+		t.Fatalf("CreateMessage failed: %v", err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- ca.WriteMessage(context.Background(), sent)
+	}()
+
+	var got socket.Message
+	if err := cb.ReadMessage(context.Background(), &got); err != nil {
+		t.Fatalf("ReadMessage failed: %v", err)
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("WriteMessage failed: %v", err)
+	}
+
+	if got.Type != sent.Type {
+		t.Errorf("Type mismatch: got %q, want %q", got.Type, sent.Type)
+	}
+}
+
+func TestFrameChannelReadRespectsContextDeadline(t *testing.T) {
+	_, cb := pipeChannels(t, DefaultMSize)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var got socket.Message
+	err := cb.ReadMessage(ctx, &got)
+	if err == nil {
+		t.Fatal("Expected ReadMessage to fail once the deadline elapsed with nothing written")
+	}
+}
+
+func TestFrameChannelOversizeFrameRejectedButConnectionStaysUsable(t *testing.T) {
+	ca, cb := pipeChannels(t, 16) // tiny ceiling so a normal message overflows it
+
+	big, err := socket.CreateMessage("context_update", &socket.ContextData{File: "a/very/long/path/to/some/file.go", Line: 1})
+	if err != nil {
+		t.Fatalf("CreateMessage failed: %v", err)
+	}
+
+	go ca.WriteMessage(context.Background(), big)
+
+	var got socket.Message
+	err = cb.ReadMessage(context.Background(), &got)
+	if err != ErrFrameTooLarge {
+		t.Fatalf("Expected ErrFrameTooLarge, got %v", err)
+	}
+
+	// The connection must still be in sync for a subsequent, correctly
+	// sized frame.
+	cb.SetMSize(DefaultMSize)
+	small, err := socket.CreateMessage("ping", socket.PingData{Timestamp: 1})
+	if err != nil {
+		t.Fatalf("CreateMessage failed: %v", err)
+	}
+	go ca.WriteMessage(context.Background(), small)
+
+	if err := cb.ReadMessage(context.Background(), &got); err != nil {
+		t.Fatalf("Expected the channel to recover after draining an oversize frame: %v", err)
+	}
+	if got.Type != "ping" {
+		t.Errorf("Type mismatch after recovery: got %q, want ping", got.Type)
+	}
+}
+
+// gatedConn wraps a net.Conn and blocks every Write on gate, tracking how
+// many Write calls are in flight at once so a test can tell whether two
+// writers were ever let onto the wire concurrently.
+type gatedConn struct {
+	net.Conn
+	gate chan struct{}
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (c *gatedConn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	c.inFlight++
+	if c.inFlight > c.maxInFlight {
+		c.maxInFlight = c.inFlight
+	}
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.inFlight--
+		c.mu.Unlock()
+	}()
+
+	<-c.gate
+	return c.Conn.Write(p)
+}
+
+func (c *gatedConn) observedConcurrentWrites() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.maxInFlight
+}
+
+func TestFrameChannelWriteMessageWaitsForAbandonedWriteBeforeUnlocking(t *testing.T) {
+	a, b := net.Pipe()
+	t.Cleanup(func() {
+		a.Close()
+		b.Close()
+	})
+
+	gate := make(chan struct{})
+	conn := &gatedConn{Conn: a, gate: gate}
+	ca := NewFrameChannel(socket.NewDeadlineConn(conn), frame.ProtoJSON, DefaultMSize)
+	t.Cleanup(func() { ca.Close() })
+
+	msg, err := socket.CreateMessage("ping", socket.PingData{Timestamp: 1})
+	if err != nil {
+		t.Fatalf("CreateMessage failed: %v", err)
+	}
+
+	// The first WriteMessage times out while its write is stuck behind
+	// gate -- simulating a stalled peer, the same way a 5s ping write
+	// would time out against an unresponsive client.
+	shortCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := ca.WriteMessage(shortCtx, msg); err == nil {
+		t.Fatal("expected the first WriteMessage to time out while its write is gated")
+	}
+
+	secondDone := make(chan struct{})
+	go func() {
+		ca.WriteMessage(context.Background(), msg)
+		close(secondDone)
+	}()
+
+	// Give a second WriteMessage a chance to (incorrectly) acquire
+	// writeMu and start writing while the first write is still gated.
+	time.Sleep(50 * time.Millisecond)
+
+	if n := conn.observedConcurrentWrites(); n > 1 {
+		t.Fatalf("observed %d Write calls in flight at once; writeMu should keep the abandoned write exclusive until it finishes", n)
+	}
+
+	close(gate)
+	<-secondDone
+}
+
+func TestNegotiateMSizeClampsToServerMax(t *testing.T) {
+	a, b := net.Pipe()
+	defer a.Close()
+	defer b.Close()
+
+	clientResult := make(chan int, 1)
+	go func() {
+		msize, err := NegotiateClient(a, MaxMSize*2)
+		if err != nil {
+			t.Errorf("NegotiateClient failed: %v", err)
+		}
+		clientResult <- msize
+	}()
+
+	serverMSize, err := NegotiateServer(b, MaxMSize)
+	if err != nil {
+		t.Fatalf("NegotiateServer failed: %v", err)
+	}
+	if serverMSize != MaxMSize {
+		t.Errorf("server msize mismatch: got %d, want %d", serverMSize, MaxMSize)
+	}
+	if got := <-clientResult; got != MaxMSize {
+		t.Errorf("client msize mismatch: got %d, want %d", got, MaxMSize)
+	}
+}
+
+func TestNegotiateMSizeDefaultsWhenClientProposesZero(t *testing.T) {
+	a, b := net.Pipe()
+	defer a.Close()
+	defer b.Close()
+
+	go NegotiateClient(a, 0)
+
+	msize, err := NegotiateServer(b, MaxMSize)
+	if err != nil {
+		t.Fatalf("NegotiateServer failed: %v", err)
+	}
+	if msize != DefaultMSize {
+		t.Errorf("msize mismatch: got %d, want %d", msize, DefaultMSize)
+	}
+}
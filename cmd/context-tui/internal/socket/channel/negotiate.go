@@ -0,0 +1,91 @@
+package channel
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket/frame"
+)
+
+// negotiateTimeout bounds the MSize handshake the same way frame's proto
+// Hello exchange bounds itself, so a stalled peer can't hang the connection
+// before any Message traffic has even started.
+const negotiateTimeout = 5 * time.Second
+
+// msizeHello is the handshake frame exchanged once, right after the proto
+// Hello, to agree on a frame size ceiling. It always travels as a plain
+// length-prefixed JSON frame, independent of whatever proto the connection
+// went on to negotiate for Message frames.
+type msizeHello struct {
+	Type  string `json:"type"`
+	MSize int    `json:"msize"`
+}
+
+// NegotiateServer reads the client's proposed MSize, caps it at maxMSize
+// (a non-positive proposal gets DefaultMSize instead), and replies with
+// the result, which is what both sides then use as their Channel's MSize.
+func NegotiateServer(conn net.Conn, maxMSize int) (int, error) {
+	conn.SetDeadline(time.Now().Add(negotiateTimeout))
+	defer conn.SetDeadline(time.Time{})
+
+	payload, err := frame.ReadFrame(conn)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read msize proposal: %w", err)
+	}
+
+	var proposal msizeHello
+	if err := json.Unmarshal(payload, &proposal); err != nil {
+		return 0, fmt.Errorf("failed to parse msize proposal: %w", err)
+	}
+
+	msize := clampMSize(proposal.MSize, maxMSize)
+
+	reply, err := json.Marshal(msizeHello{Type: "msize", MSize: msize})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal msize reply: %w", err)
+	}
+	if err := frame.WriteFrame(conn, reply); err != nil {
+		return 0, fmt.Errorf("failed to write msize reply: %w", err)
+	}
+
+	return msize, nil
+}
+
+// NegotiateClient proposes msize to the server and returns whatever it
+// clamps the proposal to.
+func NegotiateClient(conn net.Conn, msize int) (int, error) {
+	conn.SetDeadline(time.Now().Add(negotiateTimeout))
+	defer conn.SetDeadline(time.Time{})
+
+	proposal, err := json.Marshal(msizeHello{Type: "msize", MSize: msize})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal msize proposal: %w", err)
+	}
+	if err := frame.WriteFrame(conn, proposal); err != nil {
+		return 0, fmt.Errorf("failed to write msize proposal: %w", err)
+	}
+
+	payload, err := frame.ReadFrame(conn)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read msize reply: %w", err)
+	}
+
+	var reply msizeHello
+	if err := json.Unmarshal(payload, &reply); err != nil {
+		return 0, fmt.Errorf("failed to parse msize reply: %w", err)
+	}
+
+	return reply.MSize, nil
+}
+
+func clampMSize(proposed, maxMSize int) int {
+	if proposed <= 0 {
+		return DefaultMSize
+	}
+	if proposed > maxMSize {
+		return maxMSize
+	}
+	return proposed
+}
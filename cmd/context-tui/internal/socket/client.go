@@ -1,38 +1,78 @@
 package socket
 
 import (
+	"encoding/binary"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/vmihailenco/msgpack/v5"
+	"nvim-hoverfloat/cmd/context-tui/internal/lsp"
 )
 
-// Message represents the JSON message format for persistent connections
+// ProtocolVersion is the Message.Version this build writes and the
+// highest it understands. Bump it when a field changes meaning in a way
+// an older build can't safely ignore -- a purely additive, omitempty
+// field doesn't need a bump. ParseMessage rejects a message whose Version
+// is higher than this, so an older TUI build errors out on an envelope it
+// can't fully interpret instead of silently handing back a ContextData
+// with data missing.
+const ProtocolVersion = 2
+
+// Message represents the JSON message format for persistent connections.
+// The msgpack tags mirror the json ones so a Message can be round-tripped
+// through either codec, as picked by the socket/frame handshake.
 type Message struct {
-	Type      string      `json:"type"`
-	Timestamp int64       `json:"timestamp"`
-	Data      interface{} `json:"data"` // Changed from ContextData to interface{}
+	Type      string      `json:"type" msgpack:"type"`
+	Timestamp int64       `json:"timestamp" msgpack:"timestamp"`
+	Version   int         `json:"version,omitempty" msgpack:"version,omitempty"`
+	ID        string      `json:"id,omitempty" msgpack:"id,omitempty"`
+	InReplyTo string      `json:"in_reply_to,omitempty" msgpack:"in_reply_to,omitempty"`
+	SessionID uint32      `json:"session_id,omitempty" msgpack:"session_id,omitempty"`
+	Data      interface{} `json:"data" msgpack:"data"` // Changed from ContextData to interface{}
 }
 
 // ContextData represents the LSP context data
 type ContextData struct {
-	File            string         `json:"file"`
-	Line            int            `json:"line"`
-	Col             int            `json:"col"`
-	Timestamp       int64          `json:"timestamp"`
-	Hover           []string       `json:"hover,omitempty"`
-	Definition      *LocationInfo  `json:"definition,omitempty"`
-	ReferencesCount int            `json:"references_count,omitempty"`
-	References      []LocationInfo `json:"references,omitempty"`
-	ReferencesMore  int            `json:"references_more,omitempty"`
-	TypeDefinition  *LocationInfo  `json:"type_definition,omitempty"`
+	File            string               `json:"file" msgpack:"file"`
+	Line            int                  `json:"line" msgpack:"line"`
+	Col             int                  `json:"col" msgpack:"col"`
+	Timestamp       int64                `json:"timestamp" msgpack:"timestamp"`
+	Hover           HoverContent         `json:"hover,omitempty" msgpack:"hover,omitempty"`
+	Definition      *LocationInfo        `json:"definition,omitempty" msgpack:"definition,omitempty"`
+	ReferencesCount int                  `json:"references_count,omitempty" msgpack:"references_count,omitempty"`
+	References      []LocationInfo       `json:"references,omitempty" msgpack:"references,omitempty"`
+	ReferencesMore  int                  `json:"references_more,omitempty" msgpack:"references_more,omitempty"`
+	TypeDefinition  *LocationInfo        `json:"type_definition,omitempty" msgpack:"type_definition,omitempty"`
+	CompletionItems []lsp.CompletionItem `json:"completion_items,omitempty" msgpack:"completion_items,omitempty"`
+	Diagnostics     []lsp.Diagnostic     `json:"diagnostics,omitempty" msgpack:"diagnostics,omitempty"`
+	SignatureHelp   *lsp.SignatureHelp   `json:"signature_help,omitempty" msgpack:"signature_help,omitempty"`
+	CodeActions     []CodeActionInfo     `json:"code_actions,omitempty" msgpack:"code_actions,omitempty"`
+}
+
+// CodeActionInfo is one entry in ContextData.CodeActions. The TUI only
+// needs enough to list and let the user pick an action; ID is opaque and
+// resolved back into an edit by Neovim when it receives a
+// MessageTypeCodeActionInvoke for it.
+type CodeActionInfo struct {
+	ID    string `json:"id" msgpack:"id"`
+	Title string `json:"title" msgpack:"title"`
+	Kind  string `json:"kind,omitempty" msgpack:"kind,omitempty"`
+}
+
+// CodeActionInvokeData is the payload of a MessageTypeCodeActionInvoke
+// message: the TUI asking Neovim to execute the code action it listed
+// under ID.
+type CodeActionInvokeData struct {
+	ID string `json:"id" msgpack:"id"`
 }
 
 // LocationInfo represents a file location
 type LocationInfo struct {
-	File string `json:"file"`
-	Line int    `json:"line"`
-	Col  int    `json:"col"`
+	File string `json:"file" msgpack:"file"`
+	Line int    `json:"line" msgpack:"line"`
+	Col  int    `json:"col" msgpack:"col"`
 }
 
 // PingData represents ping message payload
@@ -40,10 +80,13 @@ type PingData struct {
 	Timestamp int64 `json:"timestamp"`
 }
 
-// PongData represents pong message payload
+// PongData represents pong message payload. ClientTimestamp correlated a
+// pong with its ping before Message gained ID/InReplyTo; it's kept for a
+// deprecation window so peers that haven't migrated can still match them up
+// the old way, but Message.InReplyTo is now the source of truth.
 type PongData struct {
 	Timestamp       int64 `json:"timestamp"`
-	ClientTimestamp int64 `json:"client_timestamp,omitempty"`
+	ClientTimestamp int64 `json:"client_timestamp,omitempty"` // Deprecated: use Message.InReplyTo.
 }
 
 // ErrorData represents error message payload
@@ -67,6 +110,32 @@ type DisconnectData struct {
 	Timestamp int64  `json:"timestamp"`
 }
 
+// SessionHelloData is the payload of a MessageTypeSessionHello message: a
+// client announcing the friendly name it'd like shown for its session. The
+// session's ID itself is assigned by the TUI at accept time (see
+// model.App), since the connection is already the unit of multiplexing;
+// Name is the only thing the client actually contributes here.
+type SessionHelloData struct {
+	Name string `json:"name" msgpack:"name"`
+}
+
+// SessionByeData is the payload of a MessageTypeSessionBye message: a
+// client cleanly detaching a multiplexed session rather than the socket
+// just dropping.
+type SessionByeData struct {
+	Reason string `json:"reason,omitempty" msgpack:"reason,omitempty"`
+}
+
+// ActionData is the payload of a MessageTypeAction message: the TUI
+// reporting that the user triggered a HoverSpan's action. SpanID is the
+// span's index within the ContextData.Hover it came from, since spans
+// don't carry their own identifiers.
+type ActionData struct {
+	SpanID  int             `json:"span_id" msgpack:"span_id"`
+	Kind    HoverActionKind `json:"kind" msgpack:"kind"`
+	Payload string          `json:"payload,omitempty" msgpack:"payload,omitempty"`
+}
+
 // Bubble Tea messages for communication with the main model
 
 // ContextUpdateMsg is sent when new context data is received
@@ -103,6 +172,12 @@ type DisconnectMsg struct {
 	Reason string
 }
 
+// ActionMsg is sent when the user triggers a HoverSpan's OnClick or
+// OnHover action, so the TUI can forward it to Neovim over the socket.
+type ActionMsg struct {
+	Data ActionData
+}
+
 // Bubble Tea command generators
 
 // SocketConnectedCmd returns a command that sends a connection status message
@@ -161,13 +236,25 @@ func DisconnectCmd(reason string) tea.Cmd {
 	}
 }
 
+// ActionCmd returns a command that sends a hover action message
+func ActionCmd(data ActionData) tea.Cmd {
+	return func() tea.Msg {
+		return ActionMsg{Data: data}
+	}
+}
+
 // Message parsing and creation helpers
 
 // ParseMessage parses a JSON message from the socket
 func ParseMessage(data []byte) (*Message, error) {
 	var msg Message
-	err := json.Unmarshal(data, &msg)
-	return &msg, err
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return &msg, err
+	}
+	if msg.Version > ProtocolVersion {
+		return &msg, fmt.Errorf("unsupported protocol version %d (this build understands up to %d)", msg.Version, ProtocolVersion)
+	}
+	return &msg, nil
 }
 
 // CreateMessage creates a new message with the specified type and data
@@ -175,10 +262,85 @@ func CreateMessage(msgType string, data interface{}) (*Message, error) {
 	return &Message{
 		Type:      msgType,
 		Timestamp: time.Now().UnixMilli(),
+		Version:   ProtocolVersion,
 		Data:      data,
 	}, nil
 }
 
+// messageTypeTags maps MessageType* constants to a 1-byte wire tag for
+// EncodeBinary/DecodeBinary, so the fast cursor_pos path doesn't have to
+// spend bytes on a repeated string type and millisecond-precision JSON
+// timestamp on every keystroke.
+var messageTypeTags = map[string]byte{
+	MessageTypeContextUpdate: 0x01,
+	MessageTypeCursorPos:     0x02,
+	MessageTypePing:          0x03,
+	MessageTypePong:          0x04,
+	MessageTypeError:         0x05,
+	MessageTypeStatus:        0x06,
+	MessageTypeDisconnect:    0x07,
+}
+
+// messageTypeNames is the reverse of messageTypeTags, built once at
+// package init rather than maintained by hand alongside it.
+var messageTypeNames = func() map[byte]string {
+	names := make(map[byte]string, len(messageTypeTags))
+	for name, tag := range messageTypeTags {
+		names[tag] = name
+	}
+	return names
+}()
+
+// EncodeBinary packs msg into a compact binary layout: a 1-byte type tag,
+// an 8-byte big-endian Unix-millisecond timestamp, then msg.Data
+// MessagePack-encoded. It's meant for high-frequency messages like
+// MessageTypeCursorPos where the JSON envelope's string type tag and
+// repeated field names are pure overhead; frame.EncodeMessage dispatches
+// to it when the connection negotiated frame.ProtoBinary. Callers still
+// need frame.WriteFrame to delimit the result on the wire.
+func EncodeBinary(msg *Message) ([]byte, error) {
+	tag, ok := messageTypeTags[msg.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown message type %q for binary codec", msg.Type)
+	}
+
+	payload, err := msgpack.Marshal(msg.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal binary payload: %w", err)
+	}
+
+	buf := make([]byte, 9+len(payload))
+	buf[0] = tag
+	binary.BigEndian.PutUint64(buf[1:9], uint64(msg.Timestamp))
+	copy(buf[9:], payload)
+	return buf, nil
+}
+
+// DecodeBinary unpacks a Message previously encoded with EncodeBinary. The
+// decoded Data is a generic map[string]interface{}, same as a JSON message
+// decoded off the wire, so ExtractContextData and friends work unchanged.
+func DecodeBinary(data []byte) (*Message, error) {
+	if len(data) < 9 {
+		return nil, fmt.Errorf("binary message too short: %d bytes", len(data))
+	}
+
+	msgType, ok := messageTypeNames[data[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown message type tag %#x", data[0])
+	}
+
+	msg := &Message{
+		Type:      msgType,
+		Timestamp: int64(binary.BigEndian.Uint64(data[1:9])),
+	}
+
+	if err := msgpack.Unmarshal(data[9:], &msg.Data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal binary payload: %w", err)
+	}
+
+	return msg, nil
+}
+
 // CreateContextUpdateMessage creates a context update message
 func CreateContextUpdateMessage(contextData *ContextData) (*Message, error) {
 	return CreateMessage("context_update", contextData)
@@ -191,12 +353,19 @@ func CreatePingMessage() (*Message, error) {
 	})
 }
 
-// CreatePongMessage creates a pong message
-func CreatePongMessage(clientTimestamp int64) (*Message, error) {
-	return CreateMessage("pong", PongData{
+// CreatePongMessage creates a pong message replying to the ping whose ID is
+// inReplyTo. inReplyTo may be empty for a ping that predates Message.ID,
+// in which case ClientTimestamp remains the only way to correlate it.
+func CreatePongMessage(clientTimestamp int64, inReplyTo string) (*Message, error) {
+	msg, err := CreateMessage("pong", PongData{
 		Timestamp:       time.Now().UnixMilli(),
 		ClientTimestamp: clientTimestamp,
 	})
+	if err != nil {
+		return nil, err
+	}
+	msg.InReplyTo = inReplyTo
+	return msg, nil
 }
 
 // CreateErrorMessage creates an error message
@@ -215,15 +384,42 @@ func CreateDisconnectMessage(reason string) (*Message, error) {
 	})
 }
 
+// CreateActionMessage creates a message reporting a triggered hover action
+func CreateActionMessage(data ActionData) (*Message, error) {
+	return CreateMessage(MessageTypeAction, data)
+}
+
+// CreateCodeActionInvokeMessage creates a message asking Neovim to execute
+// the code action listed under id
+func CreateCodeActionInvokeMessage(id string) (*Message, error) {
+	return CreateMessage(MessageTypeCodeActionInvoke, CodeActionInvokeData{ID: id})
+}
+
+// CreateSessionHelloMessage creates a message announcing name as the
+// friendly label for this connection's session.
+func CreateSessionHelloMessage(name string) (*Message, error) {
+	return CreateMessage(MessageTypeSessionHello, SessionHelloData{Name: name})
+}
+
+// CreateSessionByeMessage creates a message cleanly detaching this
+// connection's session.
+func CreateSessionByeMessage(reason string) (*Message, error) {
+	return CreateMessage(MessageTypeSessionBye, SessionByeData{Reason: reason})
+}
+
 // Message type constants
 const (
-	MessageTypeContextUpdate = "context_update"
-	MessageTypeCursorPos     = "cursor_pos"     // Fast cursor position updates
-	MessageTypePing          = "ping"
-	MessageTypePong          = "pong"
-	MessageTypeError         = "error"
-	MessageTypeStatus        = "status"
-	MessageTypeDisconnect    = "disconnect"
+	MessageTypeContextUpdate    = "context_update"
+	MessageTypeCursorPos        = "cursor_pos" // Fast cursor position updates
+	MessageTypePing             = "ping"
+	MessageTypePong             = "pong"
+	MessageTypeError            = "error"
+	MessageTypeStatus           = "status"
+	MessageTypeDisconnect       = "disconnect"
+	MessageTypeAction           = "action"             // User-triggered hover span action sent back to Neovim
+	MessageTypeCodeActionInvoke = "code_action_invoke" // TUI asking Neovim to execute a listed CodeActionInfo
+	MessageTypeSessionHello     = "session_hello"       // Client announcing/renaming its multiplexed session
+	MessageTypeSessionBye       = "session_bye"         // Client cleanly detaching its multiplexed session
 )
 
 // IsValidMessageType checks if a message type is valid
@@ -235,7 +431,11 @@ func IsValidMessageType(msgType string) bool {
 		MessageTypePong,
 		MessageTypeError,
 		MessageTypeStatus,
-		MessageTypeDisconnect:
+		MessageTypeDisconnect,
+		MessageTypeAction,
+		MessageTypeCodeActionInvoke,
+		MessageTypeSessionHello,
+		MessageTypeSessionBye:
 		return true
 	default:
 		return false
@@ -368,6 +568,118 @@ func (m *Message) ExtractDisconnectData() (*DisconnectData, bool) {
 	return nil, false
 }
 
+// ExtractActionData safely extracts ActionData from a message
+func (m *Message) ExtractActionData() (*ActionData, bool) {
+	if m.Type != MessageTypeAction {
+		return nil, false
+	}
+
+	// Try direct type assertion first
+	if actionData, ok := m.Data.(*ActionData); ok {
+		return actionData, true
+	}
+
+	// Try map conversion for JSON unmarshaled data
+	if dataMap, ok := m.Data.(map[string]interface{}); ok {
+		// Re-marshal and unmarshal to convert to ActionData
+		jsonBytes, err := json.Marshal(dataMap)
+		if err != nil {
+			return nil, false
+		}
+
+		var actionData ActionData
+		err = json.Unmarshal(jsonBytes, &actionData)
+		if err != nil {
+			return nil, false
+		}
+
+		return &actionData, true
+	}
+
+	return nil, false
+}
+
+// ExtractCodeActionInvokeData safely extracts CodeActionInvokeData from a message
+func (m *Message) ExtractCodeActionInvokeData() (*CodeActionInvokeData, bool) {
+	if m.Type != MessageTypeCodeActionInvoke {
+		return nil, false
+	}
+
+	if invokeData, ok := m.Data.(*CodeActionInvokeData); ok {
+		return invokeData, true
+	}
+
+	if dataMap, ok := m.Data.(map[string]interface{}); ok {
+		jsonBytes, err := json.Marshal(dataMap)
+		if err != nil {
+			return nil, false
+		}
+
+		var invokeData CodeActionInvokeData
+		if err := json.Unmarshal(jsonBytes, &invokeData); err != nil {
+			return nil, false
+		}
+
+		return &invokeData, true
+	}
+
+	return nil, false
+}
+
+// ExtractSessionHelloData safely extracts SessionHelloData from a message
+func (m *Message) ExtractSessionHelloData() (*SessionHelloData, bool) {
+	if m.Type != MessageTypeSessionHello {
+		return nil, false
+	}
+
+	if helloData, ok := m.Data.(*SessionHelloData); ok {
+		return helloData, true
+	}
+
+	if dataMap, ok := m.Data.(map[string]interface{}); ok {
+		jsonBytes, err := json.Marshal(dataMap)
+		if err != nil {
+			return nil, false
+		}
+
+		var helloData SessionHelloData
+		if err := json.Unmarshal(jsonBytes, &helloData); err != nil {
+			return nil, false
+		}
+
+		return &helloData, true
+	}
+
+	return nil, false
+}
+
+// ExtractSessionByeData safely extracts SessionByeData from a message
+func (m *Message) ExtractSessionByeData() (*SessionByeData, bool) {
+	if m.Type != MessageTypeSessionBye {
+		return nil, false
+	}
+
+	if byeData, ok := m.Data.(*SessionByeData); ok {
+		return byeData, true
+	}
+
+	if dataMap, ok := m.Data.(map[string]interface{}); ok {
+		jsonBytes, err := json.Marshal(dataMap)
+		if err != nil {
+			return nil, false
+		}
+
+		var byeData SessionByeData
+		if err := json.Unmarshal(jsonBytes, &byeData); err != nil {
+			return nil, false
+		}
+
+		return &byeData, true
+	}
+
+	return nil, false
+}
+
 // Utility methods for ContextData
 
 // FormatContextUpdate formats context data for display
@@ -400,6 +712,26 @@ func (c *ContextData) HasTypeDefinition() bool {
 	return c != nil && c.TypeDefinition != nil
 }
 
+// HasCompletionItems returns true if completion items are available
+func (c *ContextData) HasCompletionItems() bool {
+	return c != nil && len(c.CompletionItems) > 0
+}
+
+// HasDiagnostics returns true if diagnostics are available
+func (c *ContextData) HasDiagnostics() bool {
+	return c != nil && len(c.Diagnostics) > 0
+}
+
+// HasSignatureHelp returns true if signature help is available
+func (c *ContextData) HasSignatureHelp() bool {
+	return c != nil && c.SignatureHelp != nil
+}
+
+// HasCodeActions returns true if code actions are available
+func (c *ContextData) HasCodeActions() bool {
+	return c != nil && len(c.CodeActions) > 0
+}
+
 // GetTotalReferences returns the total number of references
 func (c *ContextData) GetTotalReferences() int {
 	if c == nil {
@@ -433,7 +765,11 @@ func (c *ContextData) IsEmpty() bool {
 	return !c.HasHover() &&
 		!c.HasDefinition() &&
 		!c.HasReferences() &&
-		!c.HasTypeDefinition()
+		!c.HasTypeDefinition() &&
+		!c.HasCompletionItems() &&
+		!c.HasDiagnostics() &&
+		!c.HasSignatureHelp() &&
+		!c.HasCodeActions()
 }
 
 // Clone creates a deep copy of the context data
@@ -453,7 +789,7 @@ func (c *ContextData) Clone() *ContextData {
 
 	// Clone hover data
 	if c.Hover != nil {
-		clone.Hover = make([]string, len(c.Hover))
+		clone.Hover = make(HoverContent, len(c.Hover))
 		copy(clone.Hover, c.Hover)
 	}
 
@@ -481,6 +817,34 @@ func (c *ContextData) Clone() *ContextData {
 		}
 	}
 
+	// Clone completion items
+	if c.CompletionItems != nil {
+		clone.CompletionItems = make([]lsp.CompletionItem, len(c.CompletionItems))
+		copy(clone.CompletionItems, c.CompletionItems)
+	}
+
+	// Clone diagnostics
+	if c.Diagnostics != nil {
+		clone.Diagnostics = make([]lsp.Diagnostic, len(c.Diagnostics))
+		copy(clone.Diagnostics, c.Diagnostics)
+	}
+
+	// Clone signature help
+	if c.SignatureHelp != nil {
+		sigHelp := *c.SignatureHelp
+		if c.SignatureHelp.Signatures != nil {
+			sigHelp.Signatures = make([]lsp.SignatureInformation, len(c.SignatureHelp.Signatures))
+			copy(sigHelp.Signatures, c.SignatureHelp.Signatures)
+		}
+		clone.SignatureHelp = &sigHelp
+	}
+
+	// Clone code actions
+	if c.CodeActions != nil {
+		clone.CodeActions = make([]CodeActionInfo, len(c.CodeActions))
+		copy(clone.CodeActions, c.CodeActions)
+	}
+
 	return clone
 }
 
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"testing"
 	"time"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/lsp"
 )
 
 func TestMessage(t *testing.T) {
@@ -13,7 +15,7 @@ func TestMessage(t *testing.T) {
 		Line:      42,
 		Col:       15,
 		Timestamp: time.Now().UnixMilli(),
-		Hover:     []string{"test hover"},
+		Hover:     HoverContent{{Text: "test hover"}},
 	}
 	
 	msg := Message{
@@ -40,12 +42,17 @@ func TestMessage(t *testing.T) {
 		t.Errorf("Type mismatch: got %s, want %s", parsed.Type, msg.Type)
 	}
 	
-	if parsed.Data.File != data.File {
-		t.Errorf("File mismatch: got %s, want %s", parsed.Data.File, data.File)
+	dataMap, ok := parsed.Data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected parsed Data to be a map, got %T", parsed.Data)
 	}
-	
-	if parsed.Data.Line != data.Line {
-		t.Errorf("Line mismatch: got %d, want %d", parsed.Data.Line, data.Line)
+
+	if dataMap["file"] != data.File {
+		t.Errorf("File mismatch: got %v, want %s", dataMap["file"], data.File)
+	}
+
+	if dataMap["line"] != float64(data.Line) {
+		t.Errorf("Line mismatch: got %v, want %d", dataMap["line"], data.Line)
 	}
 }
 
@@ -54,7 +61,7 @@ func TestContextData(t *testing.T) {
 		File:            "example.rs",
 		Line:            100,
 		Col:             20,
-		Hover:           []string{"fn example() -> bool"},
+		Hover:           HoverContent{{Text: "fn example() -> bool"}},
 		ReferencesCount: 5,
 		References: []LocationInfo{
 			{File: "main.rs", Line: 10, Col: 5},
@@ -86,6 +93,111 @@ func TestContextData(t *testing.T) {
 	}
 }
 
+func TestContextDataSignatureHelpAndCodeActions(t *testing.T) {
+	data := &ContextData{
+		File: "example.rs",
+		SignatureHelp: &lsp.SignatureHelp{
+			Signatures:      []lsp.SignatureInformation{{Label: "fn example(x: bool)"}},
+			ActiveParameter: 0,
+		},
+		CodeActions: []CodeActionInfo{
+			{ID: "quickfix-1", Title: "Add missing import", Kind: "quickfix"},
+		},
+	}
+
+	if !data.HasSignatureHelp() {
+		t.Error("Expected HasSignatureHelp to return true")
+	}
+	if !data.HasCodeActions() {
+		t.Error("Expected HasCodeActions to return true")
+	}
+	if data.IsEmpty() {
+		t.Error("Expected IsEmpty to return false with signature help and code actions present")
+	}
+
+	clone := data.Clone()
+	if !clone.HasSignatureHelp() || clone.SignatureHelp.Signatures[0].Label != "fn example(x: bool)" {
+		t.Error("Clone did not deep-copy SignatureHelp")
+	}
+	if len(clone.CodeActions) != 1 || clone.CodeActions[0].ID != "quickfix-1" {
+		t.Error("Clone did not deep-copy CodeActions")
+	}
+}
+
+func TestParseMessageRejectsNewerProtocolVersion(t *testing.T) {
+	raw := []byte(`{"type":"context_update","timestamp":1,"version":999,"data":{}}`)
+
+	if _, err := ParseMessage(raw); err == nil {
+		t.Error("Expected ParseMessage to reject a message from a newer protocol version")
+	}
+}
+
+func TestCreatePongMessageSetsInReplyTo(t *testing.T) {
+	pong, err := CreatePongMessage(1234, "req-1")
+	if err != nil {
+		t.Fatalf("CreatePongMessage failed: %v", err)
+	}
+
+	if pong.InReplyTo != "req-1" {
+		t.Errorf("InReplyTo mismatch: got %q, want %q", pong.InReplyTo, "req-1")
+	}
+
+	pongData, ok := pong.Data.(PongData)
+	if !ok {
+		t.Fatalf("Expected Data to be PongData, got %T", pong.Data)
+	}
+	if pongData.ClientTimestamp != 1234 {
+		t.Errorf("ClientTimestamp mismatch: got %d, want 1234", pongData.ClientTimestamp)
+	}
+}
+
+func TestEncodeDecodeBinary(t *testing.T) {
+	msg := &Message{
+		Type:      MessageTypeCursorPos,
+		Timestamp: 1234567890,
+		Data: &ContextData{
+			File: "test.go",
+			Line: 42,
+			Col:  15,
+		},
+	}
+
+	encoded, err := EncodeBinary(msg)
+	if err != nil {
+		t.Fatalf("EncodeBinary failed: %v", err)
+	}
+
+	decoded, err := DecodeBinary(encoded)
+	if err != nil {
+		t.Fatalf("DecodeBinary failed: %v", err)
+	}
+
+	if decoded.Type != msg.Type {
+		t.Errorf("Type mismatch: got %s, want %s", decoded.Type, msg.Type)
+	}
+
+	if decoded.Timestamp != msg.Timestamp {
+		t.Errorf("Timestamp mismatch: got %d, want %d", decoded.Timestamp, msg.Timestamp)
+	}
+
+	dataMap, ok := decoded.Data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected decoded Data to be a map, got %T", decoded.Data)
+	}
+
+	if dataMap["file"] != "test.go" {
+		t.Errorf("File mismatch: got %v, want test.go", dataMap["file"])
+	}
+}
+
+func TestEncodeBinaryUnknownType(t *testing.T) {
+	msg := &Message{Type: "carrier-pigeon", Timestamp: 1}
+
+	if _, err := EncodeBinary(msg); err == nil {
+		t.Error("Expected EncodeBinary to fail for an unknown message type")
+	}
+}
+
 func TestLocationInfo(t *testing.T) {
 	loc := &LocationInfo{
 		File: "/very/long/path/to/some/file/that/exceeds/normal/length.go",
@@ -0,0 +1,110 @@
+package socket
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// ErrDeadlineExceeded is returned by a deadline-guarded pipeline (decode,
+// encode) when its DeadlineGuard fires before the pipeline finishes.
+var ErrDeadlineExceeded = errors.New("deadline exceeded")
+
+// DeadlineGuard implements a single cancellable deadline using the
+// cancel-channel-plus-timer pattern from gVisor's netstack gonet adapter.
+// Unlike a kernel socket deadline, closing Done() is observable by any
+// goroutine selecting on it, including ones blocked in a CPU-bound
+// pipeline (ParseMessage, json.Marshal) rather than a syscall.
+type DeadlineGuard struct {
+	mu       sync.Mutex
+	cancelCh chan struct{}
+	timer    *time.Timer
+}
+
+// NewDeadlineGuard returns a guard with no deadline set.
+func NewDeadlineGuard() *DeadlineGuard {
+	return &DeadlineGuard{cancelCh: make(chan struct{})}
+}
+
+// Set arms the deadline for t. A zero t clears the deadline. A t already
+// in the past closes the current Done() channel immediately.
+func (d *DeadlineGuard) Set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+
+	select {
+	case <-d.cancelCh:
+		// Previous deadline already fired; replace the channel so
+		// Done() doesn't report expired before the new deadline.
+		d.cancelCh = make(chan struct{})
+	default:
+	}
+
+	if t.IsZero() {
+		d.timer = nil
+		return
+	}
+
+	if dur := time.Until(t); dur <= 0 {
+		close(d.cancelCh)
+	} else {
+		cancelCh := d.cancelCh
+		d.timer = time.AfterFunc(dur, func() { close(cancelCh) })
+	}
+}
+
+// Done returns the channel that closes when the current deadline expires.
+func (d *DeadlineGuard) Done() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.cancelCh
+}
+
+// DeadlineConn pairs a net.Conn with DeadlineGuards for reads and writes.
+// SetReadDeadline/SetWriteDeadline still arm the real kernel deadline on
+// the wrapped conn, but ReadDone()/WriteDone() additionally let a caller
+// cancel work that happens after the bytes are already off the wire --
+// decoding or encoding a Message -- which a kernel deadline can't reach.
+type DeadlineConn struct {
+	net.Conn
+	readGuard  *DeadlineGuard
+	writeGuard *DeadlineGuard
+}
+
+// NewDeadlineConn wraps conn with read and write DeadlineGuards.
+func NewDeadlineConn(conn net.Conn) *DeadlineConn {
+	return &DeadlineConn{
+		Conn:       conn,
+		readGuard:  NewDeadlineGuard(),
+		writeGuard: NewDeadlineGuard(),
+	}
+}
+
+// SetReadDeadline arms both the kernel read deadline and ReadDone().
+func (c *DeadlineConn) SetReadDeadline(t time.Time) error {
+	c.readGuard.Set(t)
+	return c.Conn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline arms both the kernel write deadline and WriteDone().
+func (c *DeadlineConn) SetWriteDeadline(t time.Time) error {
+	c.writeGuard.Set(t)
+	return c.Conn.SetWriteDeadline(t)
+}
+
+// ReadDone returns the channel that closes when the current read
+// deadline expires.
+func (c *DeadlineConn) ReadDone() <-chan struct{} {
+	return c.readGuard.Done()
+}
+
+// WriteDone returns the channel that closes when the current write
+// deadline expires.
+func (c *DeadlineConn) WriteDone() <-chan struct{} {
+	return c.writeGuard.Done()
+}
@@ -0,0 +1,59 @@
+package socket
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeadlineGuardExpires(t *testing.T) {
+	g := NewDeadlineGuard()
+	g.Set(time.Now().Add(20 * time.Millisecond))
+
+	select {
+	case <-g.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done() did not close after the deadline elapsed")
+	}
+}
+
+func TestDeadlineGuardPastDeadlineClosesImmediately(t *testing.T) {
+	g := NewDeadlineGuard()
+	g.Set(time.Now().Add(-time.Second))
+
+	select {
+	case <-g.Done():
+	default:
+		t.Fatal("Done() should already be closed for a deadline in the past")
+	}
+}
+
+func TestDeadlineGuardZeroClearsDeadline(t *testing.T) {
+	g := NewDeadlineGuard()
+	g.Set(time.Now().Add(10 * time.Millisecond))
+	g.Set(time.Time{})
+
+	select {
+	case <-g.Done():
+		t.Fatal("Done() closed even though the deadline was cleared")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDeadlineGuardResetAfterExpiry(t *testing.T) {
+	g := NewDeadlineGuard()
+	g.Set(time.Now().Add(-time.Second))
+	<-g.Done() // drain the already-expired deadline
+
+	g.Set(time.Now().Add(50 * time.Millisecond))
+	select {
+	case <-g.Done():
+		t.Fatal("Done() closed before the new deadline elapsed")
+	default:
+	}
+
+	select {
+	case <-g.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done() did not close after the new deadline elapsed")
+	}
+}
@@ -0,0 +1,172 @@
+// Package frame implements the length-prefixed wire framing negotiated
+// between the Neovim plugin and context-tui as an alternative to the
+// original newline-delimited JSON protocol. Every frame (hello and
+// message alike) is a 4-byte big-endian length prefix followed by that
+// many bytes of payload, which lets the message body itself be encoded
+// as JSON, whole-struct MessagePack, or socket's compact tagged binary
+// format (ProtoBinary) without ambiguity over where one frame ends and
+// the next begins.
+//
+// The handshake always speaks JSON: the client writes a Hello frame
+// declaring the proto it wants to use, the server replies with a Hello
+// frame confirming what was actually negotiated (falling back to
+// ProtoJSON if it doesn't recognize the request), and every frame after
+// that is encoded with the agreed codec.
+package frame
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+)
+
+// Proto identifies the wire encoding used for frame payloads after the
+// hello handshake.
+type Proto string
+
+const (
+	ProtoJSON    Proto = "json"
+	ProtoMsgpack Proto = "msgpack"
+
+	// ProtoBinary is socket.EncodeBinary/DecodeBinary's compact tagged
+	// layout, meant for connections dominated by high-frequency messages
+	// like MessageTypeCursorPos where the JSON or whole-struct msgpack
+	// envelope's type string is overhead worth shedding.
+	ProtoBinary Proto = "binary"
+)
+
+// Version is the current handshake version. Bumped if the Hello payload
+// or framing scheme ever changes incompatibly.
+const Version = 1
+
+// MaxFrameSize guards against a corrupt or malicious length prefix
+// forcing an unbounded allocation.
+const MaxFrameSize = 16 * 1024 * 1024
+
+// Hello is exchanged once, in both directions, before any Message frames
+// are sent. The client proposes a proto; the server echoes back the one
+// it actually selected (it may fall back to JSON if it doesn't recognize
+// the requested proto).
+type Hello struct {
+	Type    string `json:"type"`
+	Proto   Proto  `json:"proto"`
+	Version int    `json:"version"`
+}
+
+// IsValid reports whether p is a proto this package knows how to encode.
+func (p Proto) IsValid() bool {
+	switch p {
+	case ProtoJSON, ProtoMsgpack, ProtoBinary:
+		return true
+	default:
+		return false
+	}
+}
+
+// WriteFrame writes a single length-prefixed frame to w.
+func WriteFrame(w io.Writer, payload []byte) error {
+	var lenPrefix [4]byte
+	binary.BigEndian.PutUint32(lenPrefix[:], uint32(len(payload)))
+
+	if _, err := w.Write(lenPrefix[:]); err != nil {
+		return fmt.Errorf("failed to write frame length: %w", err)
+	}
+	if _, err := w.Write(payload); err != nil {
+		return fmt.Errorf("failed to write frame payload: %w", err)
+	}
+	return nil
+}
+
+// ReadFrame reads a single length-prefixed frame from r.
+func ReadFrame(r io.Reader) ([]byte, error) {
+	var lenPrefix [4]byte
+	if _, err := io.ReadFull(r, lenPrefix[:]); err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(lenPrefix[:])
+	if size > MaxFrameSize {
+		return nil, fmt.Errorf("frame size %d exceeds maximum %d", size, MaxFrameSize)
+	}
+
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, fmt.Errorf("failed to read frame payload: %w", err)
+	}
+	return payload, nil
+}
+
+// WriteHello sends a Hello frame.
+func WriteHello(w io.Writer, h Hello) error {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("failed to marshal hello: %w", err)
+	}
+	return WriteFrame(w, data)
+}
+
+// ReadHello reads and decodes a Hello frame.
+func ReadHello(r io.Reader) (Hello, error) {
+	var h Hello
+	payload, err := ReadFrame(r)
+	if err != nil {
+		return h, err
+	}
+	if err := json.Unmarshal(payload, &h); err != nil {
+		return h, fmt.Errorf("failed to unmarshal hello: %w", err)
+	}
+	return h, nil
+}
+
+// Negotiate picks the proto to use given what the client proposed: the
+// requested proto if it's one we support, JSON otherwise.
+func Negotiate(requested Proto) Proto {
+	if requested.IsValid() {
+		return requested
+	}
+	return ProtoJSON
+}
+
+// EncodeMessage marshals msg using the codec for proto.
+func EncodeMessage(proto Proto, msg *socket.Message) ([]byte, error) {
+	switch proto {
+	case ProtoBinary:
+		return socket.EncodeBinary(msg)
+	case ProtoMsgpack:
+		return msgpack.Marshal(msg)
+	default:
+		return json.Marshal(msg)
+	}
+}
+
+// DecodeMessage unmarshals payload into msg using the codec for proto,
+// then rejects a Version newer than this build understands the same way
+// socket.ParseMessage does for plain JSON messages read off the socket.
+func DecodeMessage(proto Proto, payload []byte, msg *socket.Message) error {
+	var err error
+	switch proto {
+	case ProtoBinary:
+		var decoded *socket.Message
+		decoded, err = socket.DecodeBinary(payload)
+		if err == nil {
+			*msg = *decoded
+		}
+	case ProtoMsgpack:
+		err = msgpack.Unmarshal(payload, msg)
+	default:
+		err = json.Unmarshal(payload, msg)
+	}
+	if err != nil {
+		return err
+	}
+
+	if msg.Version > socket.ProtocolVersion {
+		return fmt.Errorf("unsupported protocol version %d (this build understands up to %d)", msg.Version, socket.ProtocolVersion)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package frame
+
+import (
+	"bytes"
+	"testing"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+)
+
+func TestWriteReadFrame(t *testing.T) {
+	var buf bytes.Buffer
+	payload := []byte("hello world")
+
+	if err := WriteFrame(&buf, payload); err != nil {
+		t.Fatalf("WriteFrame failed: %v", err)
+	}
+
+	got, err := ReadFrame(&buf)
+	if err != nil {
+		t.Fatalf("ReadFrame failed: %v", err)
+	}
+
+	if !bytes.Equal(got, payload) {
+		t.Errorf("payload mismatch: got %q, want %q", got, payload)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		requested Proto
+		want      Proto
+	}{
+		{ProtoJSON, ProtoJSON},
+		{ProtoMsgpack, ProtoMsgpack},
+		{ProtoBinary, ProtoBinary},
+		{Proto("carrier-pigeon"), ProtoJSON},
+	}
+
+	for _, c := range cases {
+		if got := Negotiate(c.requested); got != c.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", c.requested, got, c.want)
+		}
+	}
+}
+
+func TestEncodeDecodeMessageRoundTrip(t *testing.T) {
+	for _, proto := range []Proto{ProtoJSON, ProtoMsgpack, ProtoBinary} {
+		msg := &socket.Message{
+			Type:      "context_update",
+			Timestamp: 1234,
+			Data: &socket.ContextData{
+				File: "main.go",
+				Line: 10,
+				Col:  4,
+			},
+		}
+
+		data, err := EncodeMessage(proto, msg)
+		if err != nil {
+			t.Fatalf("EncodeMessage(%s) failed: %v", proto, err)
+		}
+
+		var decoded socket.Message
+		if err := DecodeMessage(proto, data, &decoded); err != nil {
+			t.Fatalf("DecodeMessage(%s) failed: %v", proto, err)
+		}
+
+		if decoded.Type != msg.Type || decoded.Timestamp != msg.Timestamp {
+			t.Errorf("%s round-trip mismatch: got %+v, want %+v", proto, decoded, msg)
+		}
+	}
+}
+
+func TestHelloRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	sent := Hello{Type: "hello", Proto: ProtoMsgpack, Version: Version}
+
+	if err := WriteHello(&buf, sent); err != nil {
+		t.Fatalf("WriteHello failed: %v", err)
+	}
+
+	got, err := ReadHello(&buf)
+	if err != nil {
+		t.Fatalf("ReadHello failed: %v", err)
+	}
+
+	if got != sent {
+		t.Errorf("Hello round-trip mismatch: got %+v, want %+v", got, sent)
+	}
+}
@@ -0,0 +1,125 @@
+package socket
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// HoverActionKind identifies what a HoverSpan's OnClick or OnHover action
+// does when triggered. The Neovim client decides the Kind when it builds
+// the hover payload; the TUI only needs to know how to present the span
+// and where to forward the resulting ActionData.
+type HoverActionKind string
+
+const (
+	HoverActionOpenURL        HoverActionKind = "open_url"
+	HoverActionGotoLocation   HoverActionKind = "goto_location"
+	HoverActionCopy           HoverActionKind = "copy"
+	HoverActionRunCommand     HoverActionKind = "run_command"
+	HoverActionShowReferences HoverActionKind = "show_references"
+)
+
+// HoverAction is the payload of a HoverSpan's OnClick or OnHover. Exactly
+// one of Text or Location is populated depending on Kind: Text carries a
+// URL, clipboard string, or shell command; Location carries a jump target
+// for goto_location and show_references.
+type HoverAction struct {
+	Kind     HoverActionKind `json:"kind" msgpack:"kind"`
+	Text     string          `json:"text,omitempty" msgpack:"text,omitempty"`
+	Location *LocationInfo   `json:"location,omitempty" msgpack:"location,omitempty"`
+}
+
+// HoverSpan is one run of hover text, optionally clickable or hoverable.
+// Style names one of Styles' semantic lipgloss styles (e.g. "keyword",
+// "code") for the TUI to look up when rendering, so the payload stays
+// theme-agnostic instead of embedding ANSI codes or hex colors.
+type HoverSpan struct {
+	Text    string       `json:"text" msgpack:"text"`
+	Style   string       `json:"style,omitempty" msgpack:"style,omitempty"`
+	OnClick *HoverAction `json:"on_click,omitempty" msgpack:"on_click,omitempty"`
+	OnHover *HoverAction `json:"on_hover,omitempty" msgpack:"on_hover,omitempty"`
+}
+
+// IsActionable reports whether the span has a click or hover action to
+// trigger.
+func (s HoverSpan) IsActionable() bool {
+	return s.OnClick != nil || s.OnHover != nil
+}
+
+// HoverContent is the type of ContextData.Hover. It marshals as a plain
+// array of HoverSpan, but also unmarshals the pre-chunk2-2 wire format --
+// a plain array of strings, one per line -- as spans with no style or
+// action, so Neovim clients that haven't been updated keep working.
+type HoverContent []HoverSpan
+
+// UnmarshalJSON accepts either a HoverSpan array or a plain string array.
+func (h *HoverContent) UnmarshalJSON(data []byte) error {
+	var spans []HoverSpan
+	if err := json.Unmarshal(data, &spans); err == nil {
+		*h = spans
+		return nil
+	}
+
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return fmt.Errorf("hover content: not a span array or a string array: %w", err)
+	}
+
+	spans = make([]HoverSpan, len(lines))
+	for i, line := range lines {
+		spans[i] = HoverSpan{Text: line}
+	}
+	*h = spans
+	return nil
+}
+
+// DecodeMsgpack accepts either a HoverSpan array or a plain string array,
+// mirroring UnmarshalJSON for the msgpack wire codec.
+func (h *HoverContent) DecodeMsgpack(dec *msgpack.Decoder) error {
+	raw, err := dec.DecodeInterface()
+	if err != nil {
+		return err
+	}
+	if raw == nil {
+		*h = nil
+		return nil
+	}
+
+	items, ok := raw.([]interface{})
+	if !ok {
+		return fmt.Errorf("hover content: expected array, got %T", raw)
+	}
+
+	spans := make(HoverContent, len(items))
+	for i, item := range items {
+		switch v := item.(type) {
+		case string:
+			spans[i] = HoverSpan{Text: v}
+		case map[string]interface{}:
+			encoded, err := msgpack.Marshal(v)
+			if err != nil {
+				return err
+			}
+			if err := msgpack.Unmarshal(encoded, &spans[i]); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("hover content: unexpected span type %T", item)
+		}
+	}
+
+	*h = spans
+	return nil
+}
+
+// Lines returns each span's text as its own slice element, for callers (markdown
+// detection, plain-text fallbacks) that only care about the raw text.
+func (h HoverContent) Lines() []string {
+	lines := make([]string, len(h))
+	for i, span := range h {
+		lines[i] = span.Text
+	}
+	return lines
+}
@@ -0,0 +1,92 @@
+package socket
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestHoverContentUnmarshalJSONSpans(t *testing.T) {
+	raw := `[{"text":"fn example() -> bool","style":"code"},{"text":"see docs","on_click":{"kind":"open_url","text":"https://example.com"}}]`
+
+	var hover HoverContent
+	if err := json.Unmarshal([]byte(raw), &hover); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if len(hover) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(hover))
+	}
+	if hover[0].Style != "code" {
+		t.Errorf("expected style %q, got %q", "code", hover[0].Style)
+	}
+	if !hover[1].IsActionable() {
+		t.Error("expected second span to be actionable")
+	}
+	if hover[1].OnClick.Kind != HoverActionOpenURL {
+		t.Errorf("expected kind %q, got %q", HoverActionOpenURL, hover[1].OnClick.Kind)
+	}
+}
+
+func TestHoverContentUnmarshalJSONBackCompat(t *testing.T) {
+	raw := `["fn example() -> bool", "returns true on success"]`
+
+	var hover HoverContent
+	if err := json.Unmarshal([]byte(raw), &hover); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	want := []string{"fn example() -> bool", "returns true on success"}
+	got := hover.Lines()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d lines, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d: got %q, want %q", i, got[i], want[i])
+		}
+		if hover[i].IsActionable() {
+			t.Errorf("line %d: plain string span should not be actionable", i)
+		}
+	}
+}
+
+func TestHoverContentDecodeMsgpackBackCompat(t *testing.T) {
+	legacy, err := msgpack.Marshal([]string{"legacy line"})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var hover HoverContent
+	if err := msgpack.Unmarshal(legacy, &hover); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if len(hover) != 1 || hover[0].Text != "legacy line" {
+		t.Fatalf("unexpected hover content: %+v", hover)
+	}
+}
+
+func TestHoverContentDecodeMsgpackSpans(t *testing.T) {
+	spans := HoverContent{
+		{Text: "go to definition", OnClick: &HoverAction{Kind: HoverActionGotoLocation, Location: &LocationInfo{File: "main.go", Line: 10, Col: 2}}},
+	}
+
+	encoded, err := msgpack.Marshal(spans)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded HoverContent
+	if err := msgpack.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if len(decoded) != 1 || !decoded[0].IsActionable() {
+		t.Fatalf("unexpected decoded content: %+v", decoded)
+	}
+	if decoded[0].OnClick.Location.File != "main.go" {
+		t.Errorf("expected location file %q, got %q", "main.go", decoded[0].OnClick.Location.File)
+	}
+}
@@ -0,0 +1,82 @@
+package socket
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultRequestTimeout bounds how long Request waits for a reply when the
+// caller's context carries no deadline of its own.
+const DefaultRequestTimeout = 10 * time.Second
+
+// GenerateMessageID returns a random 128-bit hex-encoded ID for correlating
+// a Message carrying ID with the reply that echoes it back via InReplyTo.
+func GenerateMessageID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand failing is effectively unheard of on a real platform;
+		// fall back to a timestamp so a caller still gets a usable, if
+		// weaker, ID instead of a panic.
+		return fmt.Sprintf("fallback-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// PendingRequests correlates outbound Messages carrying an ID with the
+// inbound Message whose InReplyTo echoes it, the same way lsp.Client
+// correlates JSON-RPC calls with their responses, but keyed by the
+// random IDs this package hands out rather than sequential integers.
+type PendingRequests struct {
+	mu      sync.Mutex
+	pending map[string]chan *Message
+}
+
+// NewPendingRequests returns an empty PendingRequests table.
+func NewPendingRequests() *PendingRequests {
+	return &PendingRequests{pending: make(map[string]chan *Message)}
+}
+
+// Register allocates a fresh ID and the channel Resolve will deliver its
+// reply to. Callers must invoke the returned cancel func once they stop
+// waiting (response received, context canceled, or timeout) so the entry
+// doesn't leak.
+func (p *PendingRequests) Register() (id string, replies chan *Message, cancel func()) {
+	id = GenerateMessageID()
+	replies = make(chan *Message, 1)
+
+	p.mu.Lock()
+	p.pending[id] = replies
+	p.mu.Unlock()
+
+	cancel = func() {
+		p.mu.Lock()
+		delete(p.pending, id)
+		p.mu.Unlock()
+	}
+	return id, replies, cancel
+}
+
+// Resolve delivers msg to the request registered under msg.InReplyTo, if
+// one is still pending. It reports false if msg isn't a reply or nothing
+// is waiting for it anymore (e.g. the request already timed out).
+func (p *PendingRequests) Resolve(msg *Message) bool {
+	if msg.InReplyTo == "" {
+		return false
+	}
+
+	p.mu.Lock()
+	replies, ok := p.pending[msg.InReplyTo]
+	if ok {
+		delete(p.pending, msg.InReplyTo)
+	}
+	p.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+	replies <- msg
+	return true
+}
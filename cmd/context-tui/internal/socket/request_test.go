@@ -0,0 +1,52 @@
+package socket
+
+import "testing"
+
+func TestPendingRequestsResolveDeliversToRegisteredChannel(t *testing.T) {
+	p := NewPendingRequests()
+
+	id, replies, cancel := p.Register()
+	defer cancel()
+
+	reply := &Message{Type: "context_update", InReplyTo: id}
+	if !p.Resolve(reply) {
+		t.Fatal("Expected Resolve to find the pending request")
+	}
+
+	select {
+	case got := <-replies:
+		if got != reply {
+			t.Error("Resolve delivered a different message than expected")
+		}
+	default:
+		t.Fatal("Expected the reply to be waiting on the channel")
+	}
+}
+
+func TestPendingRequestsResolveFalseWhenNothingPending(t *testing.T) {
+	p := NewPendingRequests()
+
+	if p.Resolve(&Message{Type: "context_update", InReplyTo: "nonexistent"}) {
+		t.Error("Expected Resolve to report false for an unregistered ID")
+	}
+	if p.Resolve(&Message{Type: "context_update"}) {
+		t.Error("Expected Resolve to report false for a message with no InReplyTo")
+	}
+}
+
+func TestPendingRequestsCancelStopsFurtherDelivery(t *testing.T) {
+	p := NewPendingRequests()
+
+	id, _, cancel := p.Register()
+	cancel()
+
+	if p.Resolve(&Message{Type: "context_update", InReplyTo: id}) {
+		t.Error("Expected Resolve to report false once the request was canceled")
+	}
+}
+
+func TestGenerateMessageIDIsUnique(t *testing.T) {
+	if GenerateMessageID() == GenerateMessageID() {
+		t.Error("Expected successive GenerateMessageID calls to differ")
+	}
+}
@@ -0,0 +1,132 @@
+//go:build linux
+
+package shm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+	"nvim-hoverfloat/cmd/context-tui/internal/socket/frame"
+)
+
+// pollInterval bounds how long ReadMessage's epoll_wait blocks before
+// looping back to recheck ctx, so a caller's cancellation or a
+// concurrent Close is noticed promptly instead of only once the next
+// frame arrives.
+const pollInterval = 50 * time.Millisecond
+
+// Channel is the shared-memory Channel implementation negotiated by
+// NegotiateServer/NegotiateClient, satisfying the same method set as
+// channel.Channel.
+type Channel struct {
+	proto frame.Proto
+
+	recvRing    *ring
+	sendRing    *ring
+	recvEventFD int
+	sendEventFD int
+	epfd        int
+
+	msize int
+
+	file     *os.File
+	data     []byte
+	path     string
+	isServer bool
+
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+}
+
+// MSize returns the channel's configured message-size ceiling. Unlike
+// channel.FrameChannel, this doesn't resize anything -- the ring
+// capacity is fixed at mmap time -- it's only used to reject a payload
+// that could never fit.
+func (c *Channel) MSize() int { return c.msize }
+
+// SetMSize updates the message-size ceiling WriteMessage checks against. The
+// underlying ring stays whatever size NegotiateServer picked.
+func (c *Channel) SetMSize(n int) { c.msize = n }
+
+// ReadMessage pops the next frame from recvRing, blocking in short
+// epoll_wait slices on recvEventFD while the ring is empty.
+func (c *Channel) ReadMessage(ctx context.Context, msg *socket.Message) error {
+	for {
+		payload, err := c.recvRing.pop()
+		if err == nil {
+			return frame.DecodeMessage(c.proto, payload, msg)
+		}
+		if err != errRingEmpty {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if err := waitReadable(c.epfd, int(pollInterval/time.Millisecond)); err != nil && err != syscall.EINTR {
+			return fmt.Errorf("shm: epoll_wait: %w", err)
+		}
+		drain(c.recvEventFD)
+	}
+}
+
+// WriteMessage encodes msg and pushes it onto sendRing, retrying while
+// the ring is full (the consumer hasn't caught up yet) until ctx gives
+// up. writeMu mirrors FrameChannel's: concurrent callers are serialized
+// so two writers can't interleave a length prefix and payload.
+func (c *Channel) WriteMessage(ctx context.Context, msg *socket.Message) error {
+	payload, err := frame.EncodeMessage(c.proto, msg)
+	if err != nil {
+		return err
+	}
+	if 4+len(payload) > c.msize {
+		return fmt.Errorf("shm: message of %d bytes exceeds negotiated msize %d", len(payload), c.msize)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	for {
+		err := c.sendRing.push(payload)
+		if err == nil {
+			return signal(c.sendEventFD)
+		}
+		if err != errRingFull {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+// Close tears down the channel: both eventfds, the epoll instance, and
+// the mmap. The server side additionally unlinks the backing file under
+// /dev/shm, since it owns the segment's lifetime; a client that's simply
+// handing back its end leaves the file for the server to clean up.
+func (c *Channel) Close() error {
+	c.closeOnce.Do(func() {
+		syscall.Munmap(c.data)
+		syscall.Close(c.recvEventFD)
+		syscall.Close(c.sendEventFD)
+		syscall.Close(c.epfd)
+		if c.file != nil {
+			c.file.Close()
+		}
+		if c.isServer && c.path != "" {
+			os.Remove(c.path)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,96 @@
+//go:build linux
+
+package shm
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"time"
+)
+
+// fdsDialRetry/fdsDialAttempts bound how long recvFDs retries dialing the
+// companion socket while NegotiateServer is still in the middle of
+// creating its listener.
+const (
+	fdsDialRetry    = 5 * time.Millisecond
+	fdsDialAttempts = 40 // ~200ms total, comfortably inside negotiateTimeout
+)
+
+// sendFDs listens once on path, accepts a single connection, and passes
+// fds to it via SCM_RIGHTS before tearing the listener back down.
+func sendFDs(path string, fds ...int) error {
+	os.Remove(path)
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", path, err)
+	}
+	defer os.Remove(path)
+	defer l.Close()
+
+	l.(*net.UnixListener).SetDeadline(time.Now().Add(negotiateTimeout))
+	conn, err := l.Accept()
+	if err != nil {
+		return fmt.Errorf("accepting on %s: %w", path, err)
+	}
+	defer conn.Close()
+
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return fmt.Errorf("%s: not a Unix connection", path)
+	}
+
+	rights := syscall.UnixRights(fds...)
+	if _, _, err := unixConn.WriteMsgUnix([]byte{0}, rights, nil); err != nil {
+		return fmt.Errorf("sending fds over %s: %w", path, err)
+	}
+	return nil
+}
+
+// recvFDs dials path (retrying briefly, since the peer's listener may
+// not have started yet) and reads back exactly two fds sent by sendFDs,
+// in the order it sent them.
+func recvFDs(path string) (fd1, fd2 int, err error) {
+	var conn net.Conn
+	for attempt := 0; ; attempt++ {
+		conn, err = net.DialTimeout("unix", path, negotiateTimeout)
+		if err == nil {
+			break
+		}
+		if attempt >= fdsDialAttempts {
+			return 0, 0, fmt.Errorf("dialing %s: %w", path, err)
+		}
+		time.Sleep(fdsDialRetry)
+	}
+	defer conn.Close()
+
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return 0, 0, fmt.Errorf("%s: not a Unix connection", path)
+	}
+	unixConn.SetDeadline(time.Now().Add(negotiateTimeout))
+
+	buf := make([]byte, 1)
+	oob := make([]byte, syscall.CmsgSpace(2*4))
+	_, oobn, _, _, err := unixConn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		return 0, 0, fmt.Errorf("reading fds from %s: %w", path, err)
+	}
+
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing control message from %s: %w", path, err)
+	}
+	if len(msgs) != 1 {
+		return 0, 0, fmt.Errorf("%s: expected 1 control message, got %d", path, len(msgs))
+	}
+	fds, err := syscall.ParseUnixRights(&msgs[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing rights from %s: %w", path, err)
+	}
+	if len(fds) != 2 {
+		return 0, 0, fmt.Errorf("%s: expected 2 fds, got %d", path, len(fds))
+	}
+	return fds[0], fds[1], nil
+}
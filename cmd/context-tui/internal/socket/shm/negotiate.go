@@ -0,0 +1,177 @@
+//go:build linux
+
+package shm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"time"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket/frame"
+)
+
+// negotiateTimeout bounds both the segment hello exchange and the
+// companion .fds rights-passing handshake, mirroring
+// channel.negotiateTimeout so a stalled peer can't hang the connection
+// during setup.
+const negotiateTimeout = 5 * time.Second
+
+// shmHello is the JSON frame NegotiateServer sends over the already
+// authenticated control connection to tell the peer where its shared
+// segment lives and how big each ring is. It travels as a plain
+// length-prefixed JSON frame, the same way channel's msizeHello does,
+// independent of whichever frame.Proto was negotiated for Message
+// traffic.
+type shmHello struct {
+	Type     string `json:"type"`
+	Path     string `json:"path"`
+	RingSize uint64 `json:"ring_size"`
+}
+
+// fdsSocketPath derives the companion Unix socket NegotiateServer
+// listens on to hand the segment's two eventfds to the peer via
+// SCM_RIGHTS, keeping it alongside the control socket it's paired with.
+func fdsSocketPath(controlSocketPath string) string {
+	return controlSocketPath + ".fds"
+}
+
+// NegotiateServer creates a new shared-memory segment sized for msize,
+// tells the peer about it over conn, and hands over the segment's two
+// eventfds -- one per ring direction -- through a companion
+// "<socketPath>.fds" Unix socket using SCM_RIGHTS. The returned Channel
+// reads the "nvim to tui" ring and writes the "tui to nvim" ring.
+func NegotiateServer(conn net.Conn, socketPath string, msize int) (*Channel, error) {
+	conn.SetDeadline(time.Now().Add(negotiateTimeout))
+	defer conn.SetDeadline(time.Time{})
+
+	ringSize := ringSizeFor(msize)
+	path := fmt.Sprintf("/dev/shm/nvim_context_tui_%d", os.Getpid())
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("shm: creating segment: %w", err)
+	}
+	if err := f.Truncate(segmentSize(ringSize)); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("shm: sizing segment: %w", err)
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(segmentSize(ringSize)), syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("shm: mmap: %w", err)
+	}
+
+	nvimToTUI, err := eventfd()
+	if err != nil {
+		syscall.Munmap(data)
+		f.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("shm: creating nvim-to-tui eventfd: %w", err)
+	}
+	tuiToNvim, err := eventfd()
+	if err != nil {
+		syscall.Close(nvimToTUI)
+		syscall.Munmap(data)
+		f.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("shm: creating tui-to-nvim eventfd: %w", err)
+	}
+
+	payload, err := json.Marshal(shmHello{Type: "shm_hello", Path: path, RingSize: ringSize})
+	if err != nil {
+		return nil, fmt.Errorf("shm: marshaling hello: %w", err)
+	}
+	if err := frame.WriteFrame(conn, payload); err != nil {
+		return nil, fmt.Errorf("shm: writing hello: %w", err)
+	}
+
+	if err := sendFDs(fdsSocketPath(socketPath), nvimToTUI, tuiToNvim); err != nil {
+		return nil, fmt.Errorf("shm: handing off eventfds: %w", err)
+	}
+
+	epfd, err := syscall.EpollCreate1(0)
+	if err != nil {
+		return nil, fmt.Errorf("shm: epoll_create1: %w", err)
+	}
+	if err := syscall.EpollCtl(epfd, syscall.EPOLL_CTL_ADD, nvimToTUI, &syscall.EpollEvent{Events: syscall.EPOLLIN, Fd: int32(nvimToTUI)}); err != nil {
+		return nil, fmt.Errorf("shm: epoll_ctl: %w", err)
+	}
+
+	return &Channel{
+		proto:       frame.ProtoBinary,
+		recvRing:    &ring{data: data, off: 0, size: ringSize},
+		sendRing:    &ring{data: data, off: int(headerSize + ringSize), size: ringSize},
+		recvEventFD: nvimToTUI,
+		sendEventFD: tuiToNvim,
+		epfd:        epfd,
+		msize:       msize,
+		file:        f,
+		data:        data,
+		path:        path,
+		isServer:    true,
+	}, nil
+}
+
+// NegotiateClient reads the shmHello a matching NegotiateServer sent
+// over conn, mmaps the same segment, and receives its two eventfds over
+// the companion .fds socket. The returned Channel reads the "tui to
+// nvim" ring and writes the "nvim to tui" ring -- the mirror image of
+// NegotiateServer's Channel.
+func NegotiateClient(conn net.Conn, socketPath string) (*Channel, error) {
+	conn.SetDeadline(time.Now().Add(negotiateTimeout))
+	defer conn.SetDeadline(time.Time{})
+
+	payload, err := frame.ReadFrame(conn)
+	if err != nil {
+		return nil, fmt.Errorf("shm: reading hello: %w", err)
+	}
+	var hello shmHello
+	if err := json.Unmarshal(payload, &hello); err != nil {
+		return nil, fmt.Errorf("shm: parsing hello: %w", err)
+	}
+
+	f, err := os.OpenFile(hello.Path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("shm: opening segment: %w", err)
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(segmentSize(hello.RingSize)), syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("shm: mmap: %w", err)
+	}
+
+	nvimToTUI, tuiToNvim, err := recvFDs(fdsSocketPath(socketPath))
+	if err != nil {
+		syscall.Munmap(data)
+		f.Close()
+		return nil, fmt.Errorf("shm: receiving eventfds: %w", err)
+	}
+
+	epfd, err := syscall.EpollCreate1(0)
+	if err != nil {
+		return nil, fmt.Errorf("shm: epoll_create1: %w", err)
+	}
+	if err := syscall.EpollCtl(epfd, syscall.EPOLL_CTL_ADD, tuiToNvim, &syscall.EpollEvent{Events: syscall.EPOLLIN, Fd: int32(tuiToNvim)}); err != nil {
+		return nil, fmt.Errorf("shm: epoll_ctl: %w", err)
+	}
+
+	return &Channel{
+		proto:       frame.ProtoBinary,
+		recvRing:    &ring{data: data, off: int(headerSize + hello.RingSize), size: hello.RingSize},
+		sendRing:    &ring{data: data, off: 0, size: hello.RingSize},
+		recvEventFD: tuiToNvim,
+		sendEventFD: nvimToTUI,
+		epfd:        epfd,
+		msize:       int(hello.RingSize),
+		file:        f,
+		data:        data,
+		isServer:    false,
+	}, nil
+}
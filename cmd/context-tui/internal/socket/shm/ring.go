@@ -0,0 +1,168 @@
+//go:build linux
+
+// Package shm implements a shared-memory, SPSC-ring-buffer transport as a
+// low-latency alternative to the Unix-socket path in socket/channel: two
+// power-of-two byte rings (one per direction) mmapped from a single file
+// under /dev/shm, each with a head/tail pair of atomic counters at its
+// own offset 0. The producer writes a 4-byte big-endian length prefix
+// plus payload and bumps head; the consumer polls head, copies the
+// frame out, and bumps tail. Neither side spins: an eventfd per
+// direction, handed to the peer over a companion "<socket>.fds" Unix
+// socket via SCM_RIGHTS, lets the consumer block in epoll_wait until the
+// producer's next write instead of busy-polling head.
+//
+// Channel implements the same interface as channel.FrameChannel so
+// MessageBridge and everything above it are oblivious to which transport
+// a given connection actually uses.
+package shm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync/atomic"
+)
+
+// headerSize is the byte size of a ring's head/tail counter pair: two
+// uint64s, which mmap's page alignment already guarantees are 8-byte
+// aligned for the atomic ops below.
+const headerSize = 16
+
+// minRingSize and maxRingSize bound ringSizeFor's result regardless of
+// the negotiated MSize, so a pathological proposal can't mmap something
+// absurdly small (thrashing on every message) or absurdly large (wasting
+// /dev/shm for no latency benefit beyond maxRingSize).
+const (
+	minRingSize = 64 << 10
+	maxRingSize = 4 << 20
+)
+
+// ringSizeFor picks a power-of-two ring size of at least four times
+// msize, since a ring holding only one message's worth of data would
+// force the producer to wait for the consumer on every single write.
+// The result is clamped to [minRingSize, maxRingSize], so for an msize
+// above 1 MiB the ring is smaller than four messages, and above 4 MiB
+// smaller than one.
+func ringSizeFor(msize int) uint64 {
+	want := uint64(msize) * 4
+	size := uint64(minRingSize)
+	for size < want && size < maxRingSize {
+		size <<= 1
+	}
+	if size > maxRingSize {
+		size = maxRingSize
+	}
+	return size
+}
+
+// segmentSize is the total mmap length for two rings of ringSize bytes
+// each, header included.
+func segmentSize(ringSize uint64) int64 {
+	return 2 * int64(headerSize+ringSize)
+}
+
+// ring is one direction's view into the mmapped segment: size bytes of
+// data starting right after this ring's own head/tail header, at data[off:].
+type ring struct {
+	data []byte // the full mmapped segment, shared with the peer ring
+	off  int    // this ring's header offset within data
+	size uint64 // ring capacity in bytes; always a power of two
+}
+
+var errRingFull = fmt.Errorf("shm: ring buffer full")
+var errRingEmpty = fmt.Errorf("shm: ring buffer empty")
+
+// errPayloadTooLarge is returned by push when payload can never fit in
+// the ring regardless of how much the consumer drains, unlike
+// errRingFull which means "try again once space frees up".
+var errPayloadTooLarge = fmt.Errorf("shm: payload exceeds ring capacity")
+
+func (r *ring) head() uint64 {
+	return atomic.LoadUint64((*uint64)(headerPtr(r.data, r.off)))
+}
+
+func (r *ring) setHead(v uint64) {
+	atomic.StoreUint64((*uint64)(headerPtr(r.data, r.off)), v)
+}
+
+func (r *ring) tail() uint64 {
+	return atomic.LoadUint64((*uint64)(headerPtr(r.data, r.off+8)))
+}
+
+func (r *ring) setTail(v uint64) {
+	atomic.StoreUint64((*uint64)(headerPtr(r.data, r.off+8)), v)
+}
+
+// push appends a length-prefixed payload to the ring. It's safe to call
+// from only one producer goroutine at a time; the Channel above
+// serializes writers the same way FrameChannel does.
+func (r *ring) push(payload []byte) error {
+	total := uint64(4 + len(payload))
+	if total > r.size {
+		return errPayloadTooLarge
+	}
+
+	head := r.head()
+	tail := r.tail() // acquire: races the consumer's setTail
+	if total > r.size-(head-tail) {
+		return errRingFull
+	}
+
+	var lenPrefix [4]byte
+	binary.BigEndian.PutUint32(lenPrefix[:], uint32(len(payload)))
+	r.writeAt(head, lenPrefix[:])
+	r.writeAt(head+4, payload)
+
+	r.setHead(head + total) // release: publishes the bytes written above
+	return nil
+}
+
+// pop removes and returns the oldest length-prefixed payload in the
+// ring, or errRingEmpty if nothing is available. It's safe to call from
+// only one consumer goroutine at a time.
+func (r *ring) pop() ([]byte, error) {
+	head := r.head() // acquire: races the producer's setHead
+	tail := r.tail()
+	if head == tail {
+		return nil, errRingEmpty
+	}
+
+	var lenPrefix [4]byte
+	r.readAt(tail, lenPrefix[:])
+	size := binary.BigEndian.Uint32(lenPrefix[:])
+
+	payload := make([]byte, size)
+	r.readAt(tail+4, payload)
+
+	r.setTail(tail + 4 + uint64(size)) // release: frees the space for the producer
+	return payload, nil
+}
+
+// bufStart returns where this ring's circular data region begins in the
+// shared mmap, right past its own header.
+func (r *ring) bufStart() int {
+	return r.off + headerSize
+}
+
+// writeAt copies p into the ring's circular buffer starting at the
+// monotonically increasing position pos, wrapping as needed. pos is a
+// raw head/tail counter, not yet reduced mod size.
+func (r *ring) writeAt(pos uint64, p []byte) {
+	start := r.bufStart()
+	mask := r.size - 1
+	i := pos & mask
+	n := copy(r.data[start+int(i):start+int(r.size)], p)
+	if n < len(p) {
+		copy(r.data[start:start+int(r.size)], p[n:])
+	}
+}
+
+// readAt is writeAt's mirror for the consumer side.
+func (r *ring) readAt(pos uint64, p []byte) {
+	start := r.bufStart()
+	mask := r.size - 1
+	i := pos & mask
+	n := copy(p, r.data[start+int(i):start+int(r.size)])
+	if n < len(p) {
+		copy(p[n:], r.data[start:start+int(r.size)])
+	}
+}
@@ -0,0 +1,73 @@
+//go:build linux
+
+package shm
+
+import (
+	"bytes"
+	"testing"
+)
+
+func newTestRing(size uint64) *ring {
+	data := make([]byte, 2*(headerSize+size))
+	return &ring{data: data, off: 0, size: size}
+}
+
+func TestRingPushPopRoundTrip(t *testing.T) {
+	r := newTestRing(64)
+	msg := []byte("hello world")
+
+	if err := r.push(msg); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	got, err := r.pop()
+	if err != nil {
+		t.Fatalf("pop: %v", err)
+	}
+	if !bytes.Equal(got, msg) {
+		t.Fatalf("got %q, want %q", got, msg)
+	}
+}
+
+// TestRingWrapsAround pushes and pops enough small messages that head and
+// tail both cross the buffer boundary repeatedly, exercising writeAt/
+// readAt's wraparound copy.
+func TestRingWrapsAround(t *testing.T) {
+	r := newTestRing(32)
+	for i := 0; i < 100; i++ {
+		msg := bytes.Repeat([]byte{byte(i)}, 10)
+		if err := r.push(msg); err != nil {
+			t.Fatalf("push %d: %v", i, err)
+		}
+		got, err := r.pop()
+		if err != nil {
+			t.Fatalf("pop %d: %v", i, err)
+		}
+		if !bytes.Equal(got, msg) {
+			t.Fatalf("iter %d: got %v want %v", i, got, msg)
+		}
+	}
+}
+
+func TestRingFullReportsErrRingFull(t *testing.T) {
+	r := newTestRing(16)
+	if err := r.push(make([]byte, 12)); err != nil {
+		t.Fatalf("first push: %v", err)
+	}
+	if err := r.push(make([]byte, 12)); err != errRingFull {
+		t.Fatalf("expected errRingFull, got %v", err)
+	}
+}
+
+func TestRingEmptyReportsErrRingEmpty(t *testing.T) {
+	r := newTestRing(16)
+	if _, err := r.pop(); err != errRingEmpty {
+		t.Fatalf("expected errRingEmpty, got %v", err)
+	}
+}
+
+func TestRingRejectsPayloadLargerThanCapacity(t *testing.T) {
+	r := newTestRing(16)
+	if err := r.push(make([]byte, 100)); err != errPayloadTooLarge {
+		t.Fatalf("expected errPayloadTooLarge, got %v", err)
+	}
+}
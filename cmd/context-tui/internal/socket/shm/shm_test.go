@@ -0,0 +1,125 @@
+//go:build linux
+
+package shm
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+)
+
+// negotiatePair runs NegotiateServer and NegotiateClient against each
+// other over a net.Pipe, using socketPath only to derive the companion
+// .fds path (it's never actually dialed as a Unix socket itself, so
+// net.Pipe works fine for the hello exchange).
+func negotiatePair(t *testing.T) (*Channel, *Channel) {
+	t.Helper()
+
+	serverConn, clientConn := net.Pipe()
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+
+	type result struct {
+		ch  *Channel
+		err error
+	}
+	serverResult := make(chan result, 1)
+	go func() {
+		ch, err := NegotiateServer(serverConn, socketPath, channel_DefaultMSize)
+		serverResult <- result{ch, err}
+	}()
+
+	clientCh, err := NegotiateClient(clientConn, socketPath)
+	if err != nil {
+		t.Fatalf("NegotiateClient failed: %v", err)
+	}
+	sr := <-serverResult
+	if sr.err != nil {
+		t.Fatalf("NegotiateServer failed: %v", sr.err)
+	}
+
+	t.Cleanup(func() {
+		clientCh.Close()
+		sr.ch.Close()
+	})
+	return sr.ch, clientCh
+}
+
+// channel_DefaultMSize mirrors channel.DefaultMSize without importing
+// the channel package (which would be an import cycle-free but
+// unnecessary dependency just for a constant).
+const channel_DefaultMSize = 1 << 20
+
+func TestNegotiateServerClientRoundTrip(t *testing.T) {
+	server, client := negotiatePair(t)
+
+	sent, err := socket.CreateMessage("context_update", &socket.ContextData{File: "main.go", Line: 10})
+	if err != nil {
+		t.Fatalf("CreateMessage failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.WriteMessage(ctx, sent) }()
+
+	var got socket.Message
+	if err := client.ReadMessage(ctx, &got); err != nil {
+		t.Fatalf("ReadMessage failed: %v", err)
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("WriteMessage failed: %v", err)
+	}
+	if got.Type != sent.Type {
+		t.Errorf("Type mismatch: got %q, want %q", got.Type, sent.Type)
+	}
+}
+
+func TestChannelRoundTripBothDirections(t *testing.T) {
+	server, client := negotiatePair(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	toClient, _ := socket.CreateMessage("ping", socket.PingData{Timestamp: 1})
+	toServer, _ := socket.CreateMessage("pong", socket.PongData{Timestamp: 1, ClientTimestamp: 1})
+
+	errCh := make(chan error, 2)
+	go func() { errCh <- server.WriteMessage(ctx, toClient) }()
+	go func() { errCh <- client.WriteMessage(ctx, toServer) }()
+
+	var gotByClient, gotByServer socket.Message
+	if err := client.ReadMessage(ctx, &gotByClient); err != nil {
+		t.Fatalf("client ReadMessage failed: %v", err)
+	}
+	if err := server.ReadMessage(ctx, &gotByServer); err != nil {
+		t.Fatalf("server ReadMessage failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-errCh; err != nil {
+			t.Fatalf("WriteMessage failed: %v", err)
+		}
+	}
+
+	if gotByClient.Type != "ping" {
+		t.Errorf("client got Type %q, want ping", gotByClient.Type)
+	}
+	if gotByServer.Type != "pong" {
+		t.Errorf("server got Type %q, want pong", gotByServer.Type)
+	}
+}
+
+func TestChannelReadRespectsContextDeadline(t *testing.T) {
+	_, client := negotiatePair(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var got socket.Message
+	if err := client.ReadMessage(ctx, &got); err == nil {
+		t.Fatal("expected ReadMessage to fail once the deadline elapsed with nothing written")
+	}
+}
@@ -0,0 +1,58 @@
+//go:build linux
+
+package shm
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// headerPtr returns a pointer to the uint64 at data[off:], used by ring's
+// head/tail accessors. mmap always returns page-aligned memory, so any
+// 8-byte-aligned off (headerSize and its multiples all are) satisfies
+// atomic's alignment requirement without any further bookkeeping.
+func headerPtr(data []byte, off int) unsafe.Pointer {
+	return unsafe.Pointer(&data[off])
+}
+
+// eventfd creates a Linux eventfd with an initial counter of 0, opened
+// EFD_NONBLOCK (same bit as O_NONBLOCK) so drain's read after a wakeup
+// can't itself block if a racing signal already drained the counter.
+// Unlike golang.org/x/sys/unix, the standard syscall package doesn't
+// wrap eventfd2 itself, so this issues the raw eventfd2 syscall directly.
+func eventfd() (int, error) {
+	fd, _, errno := syscall.Syscall(syscall.SYS_EVENTFD2, 0, uintptr(syscall.O_NONBLOCK), 0)
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(fd), nil
+}
+
+// signal bumps fd's counter by one, waking anything blocked in
+// epoll_wait on it.
+func signal(fd int) error {
+	var buf [8]byte
+	buf[7] = 1
+	_, err := syscall.Write(fd, buf[:])
+	return err
+}
+
+// drain resets fd's counter to zero after an epoll_wait wakeup, the
+// standard eventfd read-to-rearm step. fd is EFD_NONBLOCK, so a spurious
+// call with nothing pending (epoll_wait timing out, or a second consumer
+// iteration losing the race) returns EAGAIN instead of blocking.
+func drain(fd int) {
+	var buf [8]byte
+	syscall.Read(fd, buf[:])
+}
+
+// waitReadable blocks until fd (an eventfd registered for EPOLLIN) is
+// signaled or msec elapses, whichever comes first. It's used with a
+// short, bounded msec rather than -1 so a caller can still notice
+// context cancellation or Channel.Close between wakeups instead of
+// blocking in the syscall indefinitely.
+func waitReadable(epfd int, msec int) error {
+	events := make([]syscall.EpollEvent, 1)
+	_, err := syscall.EpollWait(epfd, events, msec)
+	return err
+}
@@ -0,0 +1,62 @@
+package styles
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"nvim-hoverfloat/cmd/context-tui/internal/config"
+)
+
+// applyAesthetics overlays the active aesthetics.conf styleset (see
+// internal/config) on top of the styles NewWithRenderer already built
+// from the JSON theme, so a user's [styles.*] layers can tweak individual
+// UI elements without having to fork a whole theme. A nil Config (no
+// --aesthetics flag given, or nothing found by discovery) leaves s
+// unchanged.
+func (s *Styles) applyAesthetics() *Styles {
+	cfg := config.Config.Load()
+	if cfg == nil {
+		return s
+	}
+
+	accents := cfg.Colors.Accent
+	resolve := func(layers ...string) config.StyleSpec {
+		return cfg.ResolveStyle(layers...)
+	}
+
+	s.SectionHeader = applyStyleSpec(s.SectionHeader, resolve("section_header"), accents)
+	s.Code = applyStyleSpec(s.Code, resolve("code_block"), accents)
+	s.StatusError = applyStyleSpec(s.StatusError, resolve("symbol_default", "symbol_error"), accents)
+	s.FocusedBorder = applyStyleSpec(s.FocusedBorder, resolve("border_focused"), accents)
+
+	return s
+}
+
+// applyStyleSpec overlays the fields spec explicitly sets onto style,
+// leaving every field it doesn't mention untouched. A color spec that
+// fails to parse is skipped rather than aborting the whole style, since
+// one bad field in a styleset shouldn't blank out the rest of it.
+func applyStyleSpec(style lipgloss.Style, spec config.StyleSpec, accents config.AccentColors) lipgloss.Style {
+	if spec.Fg != nil {
+		if c, err := config.ParseColor(*spec.Fg, accents); err == nil {
+			style = style.Foreground(lipgloss.Color(c.Hex()))
+		}
+	}
+	if spec.Bg != nil {
+		if c, err := config.ParseColor(*spec.Bg, accents); err == nil {
+			style = style.Background(lipgloss.Color(c.Hex()))
+		}
+	}
+	if spec.Bold != nil {
+		style = style.Bold(*spec.Bold)
+	}
+	if spec.Italic != nil {
+		style = style.Italic(*spec.Italic)
+	}
+	if spec.Underline != nil {
+		style = style.Underline(*spec.Underline)
+	}
+	if spec.Reverse != nil {
+		style = style.Reverse(*spec.Reverse)
+	}
+	return style
+}
@@ -1,44 +1,33 @@
 package styles
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Tokyo Night color scheme (hardcoded for simplicity)
-const (
-	// Background colors
-	bgPrimary   = "#1a1b26"
-	bgSecondary = "#24283b"
-	bgAccent    = "#414868"
-	bgFloating  = "#16161e"
-	bgCodeBlock = "#1f2335"
-	bgSelection = "#283457"
-
-	// Foreground colors
-	fgPrimary   = "#c0caf5"
-	fgSecondary = "#a9b1d6"
-	fgComment   = "#565f89"
-	fgDark      = "#545c7e"
-	fgInverse   = "#1a1b26"
-
-	// Accent colors
-	colorBlue   = "#7aa2f7"
-	colorGreen  = "#9ece6a"
-	colorYellow = "#e0af68"
-	colorPurple = "#bb9af7"
-	colorRed    = "#f7768e"
-	colorOrange = "#ff9e64"
-	colorCyan   = "#7dcfff"
-
-	// Semantic colors
-	colorBorder    = "#27a1b9"
-	colorBorderDim = "#414868"
-	colorFocus     = "#7aa2f7"
-	colorError     = "#f7768e"
-	colorWarning   = "#e0af68"
-	colorSuccess   = "#9ece6a"
-	colorInfo      = "#7aa2f7"
-)
+// Renderer is bound to os.Stderr rather than lipgloss's default (which
+// inspects os.Stdout), because main.go sends program output to stderr via
+// tea.WithOutput(os.Stderr) to keep stdout clean for the TUI_READY
+// readiness signal. Binding our own renderer keeps color-profile
+// detection (truecolor vs 256-color vs no-color) accurate despite that.
+var Renderer = lipgloss.NewRenderer(os.Stderr)
+
+// fallbackTheme is used whenever the requested theme can't be loaded (a
+// typo'd NVIM_HOVERFLOAT_THEME, a missing user override, or a corrupt
+// custom theme file), so the TUI always has colors to render with instead
+// of failing to start.
+var fallbackTheme = mustLoadBuiltin(DefaultThemeName)
+
+func mustLoadBuiltin(name string) Theme {
+	theme, err := LoadTheme(name)
+	if err != nil {
+		panic(fmt.Sprintf("styles: built-in theme %q failed to load: %v", name, err))
+	}
+	return theme
+}
 
 // Styles contains all styled components
 type Styles struct {
@@ -83,192 +72,228 @@ type Styles struct {
 	// Focus indicators
 	FocusedBorder   lipgloss.Style
 	UnfocusedBorder lipgloss.Style
+
+	// Theme is the palette these styles were generated from, kept around
+	// so callers can tell which theme is active (e.g. to cycle to the
+	// next one) without threading it through separately.
+	Theme Theme
+
+	// useNerdFontIcons selects Nerd Font glyphs over emoji in FileIcon and
+	// StatusIcon. Defaults to Theme.UseNerdFontIcons; WithNerdFontIcons
+	// overrides it explicitly.
+	useNerdFontIcons bool
 }
 
-// New creates a new Styles instance with all styles initialized
+// New creates a new Styles instance using the theme selected by
+// NVIM_HOVERFLOAT_THEME (falling back to DefaultThemeName), or the
+// built-in default if that theme can't be loaded.
 func New() *Styles {
-	s := &Styles{}
+	theme, err := LoadTheme(ResolveThemeName())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "styles: %v, falling back to %q\n", err, DefaultThemeName)
+		theme = fallbackTheme
+	}
+	return NewWithRenderer(Renderer, theme)
+}
+
+// NewWithTheme creates a new Styles instance with all styles initialized
+// from the given theme, so themes can be hot-swapped at runtime.
+func NewWithTheme(theme Theme) *Styles {
+	return NewWithRenderer(Renderer, theme)
+}
+
+// NewWithRenderer creates a new Styles instance whose styles are bound to
+// r, so color-profile detection (and thus truecolor/256-color/no-color
+// degradation of the theme's hex colors) follows r's output rather than
+// lipgloss's package-level default.
+func NewWithRenderer(r *lipgloss.Renderer, theme Theme) *Styles {
+	s := &Styles{Theme: theme, useNerdFontIcons: theme.UseNerdFontIcons}
+
+	bg := theme.Background
+	fg := theme.Foreground
+	ac := theme.Accent
+	sem := theme.Semantic
 
 	// Base styles
-	s.Base = lipgloss.NewStyle().
-		Background(lipgloss.Color(bgPrimary)).
-		Foreground(lipgloss.Color(fgPrimary))
+	s.Base = r.NewStyle().
+		Background(lipgloss.Color(bg.Primary)).
+		Foreground(lipgloss.Color(fg.Primary))
 
 	// Layout styles
-	s.Header = lipgloss.NewStyle().
-		Background(lipgloss.Color(bgAccent)).
-		Foreground(lipgloss.Color(colorBlue)).
+	s.Header = r.NewStyle().
+		Background(lipgloss.Color(bg.Accent)).
+		Foreground(lipgloss.Color(ac.Blue)).
 		Bold(true).
 		Padding(0, 2).
 		Align(lipgloss.Left)
 
-	s.Footer = lipgloss.NewStyle().
-		Background(lipgloss.Color(bgSecondary)).
-		Foreground(lipgloss.Color(fgComment)).
+	s.Footer = r.NewStyle().
+		Background(lipgloss.Color(bg.Secondary)).
+		Foreground(lipgloss.Color(fg.Comment)).
 		Padding(0, 2).
 		Align(lipgloss.Left)
 
-	s.Content = lipgloss.NewStyle().
-		Background(lipgloss.Color(bgPrimary)).
-		Foreground(lipgloss.Color(fgPrimary)).
+	s.Content = r.NewStyle().
+		Background(lipgloss.Color(bg.Primary)).
+		Foreground(lipgloss.Color(fg.Primary)).
 		Padding(0)
 
-	s.Sidebar = lipgloss.NewStyle().
-		Background(lipgloss.Color(bgSecondary)).
-		Foreground(lipgloss.Color(fgSecondary)).
+	s.Sidebar = r.NewStyle().
+		Background(lipgloss.Color(bg.Secondary)).
+		Foreground(lipgloss.Color(fg.Secondary)).
 		Padding(1).
 		Border(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color(colorBorderDim))
+		BorderForeground(lipgloss.Color(sem.BorderDim))
 
 	// Section styles
-	s.Section = lipgloss.NewStyle().
-		Background(lipgloss.Color(bgSecondary)).
-		Foreground(lipgloss.Color(fgPrimary)).
+	s.Section = r.NewStyle().
+		Background(lipgloss.Color(bg.Secondary)).
+		Foreground(lipgloss.Color(fg.Primary)).
 		MarginBottom(1).
 		Padding(1, 2).
 		Border(lipgloss.NormalBorder(), false, false, true, false).
-		BorderForeground(lipgloss.Color(colorBorderDim))
+		BorderForeground(lipgloss.Color(sem.BorderDim))
 
-	s.SectionFocused = lipgloss.NewStyle().
-		Background(lipgloss.Color(bgSelection)).
-		Foreground(lipgloss.Color(fgPrimary)).
+	s.SectionFocused = r.NewStyle().
+		Background(lipgloss.Color(bg.Selection)).
+		Foreground(lipgloss.Color(fg.Primary)).
 		MarginBottom(1).
 		Padding(1, 2).
 		Border(lipgloss.ThickBorder(), false, false, true, false).
-		BorderForeground(lipgloss.Color(colorFocus))
+		BorderForeground(lipgloss.Color(sem.Focus))
 
-	s.SectionHeader = lipgloss.NewStyle().
-		Background(lipgloss.Color(bgAccent)).
-		Foreground(lipgloss.Color(colorYellow)).
+	s.SectionHeader = r.NewStyle().
+		Background(lipgloss.Color(bg.Accent)).
+		Foreground(lipgloss.Color(ac.Yellow)).
 		Bold(true).
 		Padding(0, 2).
 		MarginBottom(1)
 
-	s.SectionContent = lipgloss.NewStyle().
-		Background(lipgloss.Color(bgSecondary)).
-		Foreground(lipgloss.Color(fgPrimary)).
+	s.SectionContent = r.NewStyle().
+		Background(lipgloss.Color(bg.Secondary)).
+		Foreground(lipgloss.Color(fg.Primary)).
 		Padding(0, 2)
 
 	// Menu styles
-	s.Menu = lipgloss.NewStyle().
-		Background(lipgloss.Color(bgFloating)).
-		Foreground(lipgloss.Color(fgPrimary)).
+	s.Menu = r.NewStyle().
+		Background(lipgloss.Color(bg.Floating)).
+		Foreground(lipgloss.Color(fg.Primary)).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(colorBorder)).
+		BorderForeground(lipgloss.Color(sem.Border)).
 		Padding(1).
 		Width(30)
 
-	s.MenuItem = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(fgSecondary)).
+	s.MenuItem = r.NewStyle().
+		Foreground(lipgloss.Color(fg.Secondary)).
 		Padding(0, 1)
 
-	s.MenuItemActive = s.MenuItem.Copy().
-		Background(lipgloss.Color(colorFocus)).
-		Foreground(lipgloss.Color(fgInverse)).
+	s.MenuItemActive = s.MenuItem.
+		Background(lipgloss.Color(sem.Focus)).
+		Foreground(lipgloss.Color(fg.Inverse)).
 		Bold(true)
 
 	// Text styles
-	s.Title = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(colorBlue)).
+	s.Title = r.NewStyle().
+		Foreground(lipgloss.Color(ac.Blue)).
 		Bold(true).
 		MarginBottom(1)
 
-	s.Subtitle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(colorPurple)).
+	s.Subtitle = r.NewStyle().
+		Foreground(lipgloss.Color(ac.Purple)).
 		Bold(true)
 
-	s.Body = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(fgPrimary))
+	s.Body = r.NewStyle().
+		Foreground(lipgloss.Color(fg.Primary))
 
-	s.Code = lipgloss.NewStyle().
-		Background(lipgloss.Color(bgCodeBlock)).
-		Foreground(lipgloss.Color(colorGreen)).
+	s.Code = r.NewStyle().
+		Background(lipgloss.Color(bg.CodeBlock)).
+		Foreground(lipgloss.Color(ac.Green)).
 		Padding(0, 1)
 
-	s.Comment = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(fgComment)).
+	s.Comment = r.NewStyle().
+		Foreground(lipgloss.Color(fg.Comment)).
 		Italic(true)
 
-	s.Highlight = lipgloss.NewStyle().
-		Background(lipgloss.Color(colorYellow)).
-		Foreground(lipgloss.Color(fgInverse)).
+	s.Highlight = r.NewStyle().
+		Background(lipgloss.Color(ac.Yellow)).
+		Foreground(lipgloss.Color(fg.Inverse)).
 		Bold(true)
 
 	// Status styles
-	s.StatusGood = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(colorSuccess)).
+	s.StatusGood = r.NewStyle().
+		Foreground(lipgloss.Color(sem.Success)).
 		Bold(true)
 
-	s.StatusWarning = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(colorWarning)).
+	s.StatusWarning = r.NewStyle().
+		Foreground(lipgloss.Color(sem.Warning)).
 		Bold(true)
 
-	s.StatusError = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(colorError)).
+	s.StatusError = r.NewStyle().
+		Foreground(lipgloss.Color(sem.Error)).
 		Bold(true)
 
-	s.StatusInfo = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(colorInfo)).
+	s.StatusInfo = r.NewStyle().
+		Foreground(lipgloss.Color(sem.Info)).
 		Bold(true)
 
 	// Special element styles
-	s.Border = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(colorBorder))
+	s.Border = r.NewStyle().
+		Foreground(lipgloss.Color(sem.Border))
 
-	s.Keybind = lipgloss.NewStyle().
-		Background(lipgloss.Color(bgAccent)).
-		Foreground(lipgloss.Color(colorOrange)).
+	s.Keybind = r.NewStyle().
+		Background(lipgloss.Color(bg.Accent)).
+		Foreground(lipgloss.Color(ac.Orange)).
 		Bold(true).
 		Padding(0, 1)
 
-	s.Path = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(colorCyan)).
+	s.Path = r.NewStyle().
+		Foreground(lipgloss.Color(ac.Cyan)).
 		Underline(true)
 
-	s.LineNumber = lipgloss.NewStyle().
-		Foreground(lipgloss.Color(fgComment)).
+	s.LineNumber = r.NewStyle().
+		Foreground(lipgloss.Color(fg.Comment)).
 		Width(4).
 		Align(lipgloss.Right)
 
 	// Focus indicators
-	s.FocusedBorder = lipgloss.NewStyle().
+	s.FocusedBorder = r.NewStyle().
 		Border(lipgloss.ThickBorder()).
-		BorderForeground(lipgloss.Color(colorFocus))
+		BorderForeground(lipgloss.Color(sem.Focus))
 
-	s.UnfocusedBorder = lipgloss.NewStyle().
+	s.UnfocusedBorder = r.NewStyle().
 		Border(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color(colorBorderDim))
+		BorderForeground(lipgloss.Color(sem.BorderDim))
 
-	return s
+	return s.applyAesthetics()
 }
 
-// WithWidth returns a copy of the style with the specified width
+// WithWidth returns the style with the specified width
 func (s *Styles) WithWidth(style lipgloss.Style, width int) lipgloss.Style {
-	return style.Copy().Width(width)
+	return style.Width(width)
 }
 
-// WithHeight returns a copy of the style with the specified height
+// WithHeight returns the style with the specified height
 func (s *Styles) WithHeight(style lipgloss.Style, height int) lipgloss.Style {
-	return style.Copy().Height(height)
+	return style.Height(height)
 }
 
-// WithSize returns a copy of the style with the specified width and height
+// WithSize returns the style with the specified width and height
 func (s *Styles) WithSize(style lipgloss.Style, width, height int) lipgloss.Style {
-	return style.Copy().Width(width).Height(height)
+	return style.Width(width).Height(height)
 }
 
 // Focused returns the focused version of a section style
 func (s *Styles) Focused(style lipgloss.Style) lipgloss.Style {
-	return style.Copy().
+	return style.
 		Border(lipgloss.ThickBorder()).
-		BorderForeground(lipgloss.Color(colorFocus))
+		BorderForeground(lipgloss.Color(s.Theme.Semantic.Focus))
 }
 
 // Unfocused returns the unfocused version of a section style
 func (s *Styles) Unfocused(style lipgloss.Style) lipgloss.Style {
-	return style.Copy().
+	return style.
 		Border(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color(colorBorderDim))
+		BorderForeground(lipgloss.Color(s.Theme.Semantic.BorderDim))
 }
 
 // ToggleStatus returns a style based on boolean state
@@ -279,53 +304,103 @@ func (s *Styles) ToggleStatus(enabled bool) lipgloss.Style {
 	return s.StatusError
 }
 
-// PriorityColor returns a color based on priority level
-func (s *Styles) PriorityColor(level int) lipgloss.Style {
-	switch level {
-	case 0:
-		return s.StatusError // High priority
+// PriorityColor returns a style for an LSP diagnostic severity (1=Error,
+// 2=Warning, 3=Information, 4=Hint, per the textDocument/publishDiagnostics
+// spec). Anything outside that range is treated as informational.
+func (s *Styles) PriorityColor(severity int) lipgloss.Style {
+	switch severity {
 	case 1:
-		return s.StatusWarning // Medium priority
+		return s.StatusError
+	case 2:
+		return s.StatusWarning
+	case 4:
+		return s.Comment
 	default:
-		return s.StatusInfo // Low priority
+		return s.StatusInfo
 	}
 }
 
-// FileIcon returns an appropriate icon for the file type
+// icon pairs an emoji with its Nerd Font (devicon/PUA) equivalent, so a
+// single lookup can serve either icon set depending on useNerdFontIcons.
+type icon struct {
+	Emoji    string
+	NerdFont string
+}
+
+// extensionIcons maps filepath.Ext results to icons. Unlike the old
+// hand-rolled suffix slicing, this can't crash on short names and is easy
+// to extend with new languages.
+var extensionIcons = map[string]icon{
+	".go":   {"🐹", ""},
+	".rs":   {"🦀", ""},
+	".py":   {"🐍", ""},
+	".js":   {"📜", ""},
+	".jsx":  {"📜", ""},
+	".ts":   {"📘", ""},
+	".tsx":  {"📘", ""},
+	".rb":   {"💎", ""},
+	".java": {"☕", ""},
+	".c":    {"🇨", ""},
+	".h":    {"🇨", ""},
+	".cpp":  {"🇨", ""},
+	".hpp":  {"🇨", ""},
+	".json": {"📋", ""},
+	".lua":  {"🌙", ""},
+	".md":   {"📝", ""},
+	".yaml": {"⚙️", ""},
+	".yml":  {"⚙️", ""},
+	".toml": {"⚙️", ""},
+	".sh":   {"🐚", ""},
+}
+
+// basenameIcons covers well-known extensionless files that extensionIcons
+// can't key on.
+var basenameIcons = map[string]icon{
+	"Makefile":   {"🔧", ""},
+	"Dockerfile": {"🐳", ""},
+}
+
+var defaultFileIcon = icon{"📄", ""}
+
+var statusIcons = map[string]icon{
+	"connected":    {"🔗", ""},
+	"disconnected": {"❌", ""},
+	"error":        {"⚠️", ""},
+	"loading":      {"⏳", ""},
+}
+
+var defaultStatusIcon = icon{"ℹ️", ""}
+
+// WithNerdFontIcons overrides whether FileIcon and StatusIcon render Nerd
+// Font glyphs instead of emoji, regardless of what the active theme
+// specifies in use_nerd_font_icons.
+func (s *Styles) WithNerdFontIcons(enabled bool) {
+	s.useNerdFontIcons = enabled
+}
+
+func (s *Styles) pickIcon(i icon) string {
+	if s.useNerdFontIcons {
+		return i.NerdFont
+	}
+	return i.Emoji
+}
+
+// FileIcon returns an appropriate icon for the file type, keyed first by
+// well-known basename (e.g. Makefile, Dockerfile) and then by extension.
 func (s *Styles) FileIcon(filename string) string {
-	// Simple file type detection
-	switch {
-	case len(filename) > 3 && filename[len(filename)-3:] == ".go":
-		return "🐹"
-	case len(filename) > 3 && filename[len(filename)-3:] == ".rs":
-		return "🦀"
-	case len(filename) > 3 && filename[len(filename)-3:] == ".py":
-		return "🐍"
-	case len(filename) > 3 && filename[len(filename)-3:] == ".js":
-		return "📜"
-	case len(filename) > 3 && filename[len(filename)-3:] == ".ts":
-		return "📘"
-	case len(filename) > 5 && filename[len(filename)-5:] == ".json":
-		return "📋"
-	case len(filename) > 4 && filename[len(filename)-4:] == ".lua":
-		return "🌙"
-	default:
-		return "📄"
+	if i, ok := basenameIcons[filepath.Base(filename)]; ok {
+		return s.pickIcon(i)
 	}
+	if i, ok := extensionIcons[filepath.Ext(filename)]; ok {
+		return s.pickIcon(i)
+	}
+	return s.pickIcon(defaultFileIcon)
 }
 
 // StatusIcon returns an appropriate status icon
 func (s *Styles) StatusIcon(status string) string {
-	switch status {
-	case "connected":
-		return "🔗"
-	case "disconnected":
-		return "❌"
-	case "error":
-		return "⚠️"
-	case "loading":
-		return "⏳"
-	default:
-		return "ℹ️"
+	if i, ok := statusIcons[status]; ok {
+		return s.pickIcon(i)
 	}
-}
\ No newline at end of file
+	return s.pickIcon(defaultStatusIcon)
+}
@@ -0,0 +1,111 @@
+package styles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadThemeBuiltin(t *testing.T) {
+	theme, err := LoadTheme("dracula")
+	if err != nil {
+		t.Fatalf("LoadTheme failed: %v", err)
+	}
+	if theme.Name != "dracula" {
+		t.Errorf("theme.Name = %q, want %q", theme.Name, "dracula")
+	}
+}
+
+func TestLoadThemeUnknownNameErrors(t *testing.T) {
+	if _, err := LoadTheme("does-not-exist"); err == nil {
+		t.Error("Expected LoadTheme to error for an unknown theme name")
+	}
+}
+
+func TestLoadThemeFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.json")
+	if err := os.WriteFile(path, []byte(`{"name":"custom"}`), 0o600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	theme, err := LoadThemeFile(path)
+	if err != nil {
+		t.Fatalf("LoadThemeFile failed: %v", err)
+	}
+	if theme.Name != "custom" {
+		t.Errorf("theme.Name = %q, want %q", theme.Name, "custom")
+	}
+}
+
+func TestLoadThemeFileMissingErrors(t *testing.T) {
+	if _, err := LoadThemeFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected LoadThemeFile to error for a missing file")
+	}
+}
+
+func TestResolveThemeNameEnvOverride(t *testing.T) {
+	t.Setenv("NVIM_HOVERFLOAT_THEME", "nord")
+
+	if got := ResolveThemeName(); got != "nord" {
+		t.Errorf("ResolveThemeName() = %q, want %q", got, "nord")
+	}
+}
+
+func TestPriorityColorMapsKnownSeverities(t *testing.T) {
+	s := New()
+
+	cases := []struct {
+		severity int
+		want     string
+	}{
+		{1, "error"},
+		{2, "warning"},
+		{4, "comment"},
+		{3, "info"},
+		{0, "info"},
+		{99, "info"},
+	}
+
+	for _, c := range cases {
+		got := s.PriorityColor(c.severity)
+		var want string
+		switch c.want {
+		case "error":
+			want = s.StatusError.Render("x")
+		case "warning":
+			want = s.StatusWarning.Render("x")
+		case "comment":
+			want = s.Comment.Render("x")
+		default:
+			want = s.StatusInfo.Render("x")
+		}
+		if got.Render("x") != want {
+			t.Errorf("PriorityColor(%d) rendered %q, want %q", c.severity, got.Render("x"), want)
+		}
+	}
+}
+
+func TestFileIconByExtensionAndBasename(t *testing.T) {
+	s := New()
+
+	if got := s.FileIcon("main.go"); got != "🐹" {
+		t.Errorf("FileIcon(main.go) = %q, want 🐹", got)
+	}
+	if got := s.FileIcon("Makefile"); got != "🔧" {
+		t.Errorf("FileIcon(Makefile) = %q, want 🔧", got)
+	}
+	if got := s.FileIcon("README"); got != "📄" {
+		t.Errorf("FileIcon(README) = %q, want the default icon", got)
+	}
+}
+
+func TestStatusIconKnownAndDefault(t *testing.T) {
+	s := New()
+
+	if got := s.StatusIcon("connected"); got != "🔗" {
+		t.Errorf("StatusIcon(connected) = %q, want 🔗", got)
+	}
+	if got := s.StatusIcon("unknown-status"); got != "ℹ️" {
+		t.Errorf("StatusIcon(unknown-status) = %q, want the default icon", got)
+	}
+}
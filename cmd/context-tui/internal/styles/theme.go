@@ -0,0 +1,127 @@
+package styles
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/muesli/termenv"
+	"nvim-hoverfloat/cmd/context-tui/internal/assets"
+)
+
+// Theme defines a full color palette for the TUI, loadable from a JSON
+// file so users can define custom palettes without recompiling. Its shape
+// mirrors the built-in theme files under cmd/context-tui/assets/styles,
+// and a user override of the same name under
+// ~/.config/nvim-hoverfloat/themes takes precedence over a bundled one.
+type Theme struct {
+	Name string `json:"name"`
+
+	// UseNerdFontIcons selects devicon/Nerd Font glyphs for FileIcon and
+	// StatusIcon instead of emoji. Set this per-theme so a single config
+	// field controls a theme's whole visual identity; Styles.WithNerdFontIcons
+	// overrides it at runtime regardless of what the active theme specifies.
+	UseNerdFontIcons bool `json:"use_nerd_font_icons,omitempty"`
+
+	Background struct {
+		Primary   string `json:"primary"`
+		Secondary string `json:"secondary"`
+		Accent    string `json:"accent"`
+		Floating  string `json:"floating"`
+		CodeBlock string `json:"code_block"`
+		Selection string `json:"selection"`
+	} `json:"background"`
+
+	Foreground struct {
+		Primary   string `json:"primary"`
+		Secondary string `json:"secondary"`
+		Comment   string `json:"comment"`
+		Dark      string `json:"dark"`
+		Inverse   string `json:"inverse"`
+	} `json:"foreground"`
+
+	// Accent holds the per-syntax-kind colors used for titles, keybind
+	// hints, and other non-semantic highlights.
+	Accent struct {
+		Blue   string `json:"blue"`
+		Green  string `json:"green"`
+		Yellow string `json:"yellow"`
+		Purple string `json:"purple"`
+		Red    string `json:"red"`
+		Orange string `json:"orange"`
+		Cyan   string `json:"cyan"`
+	} `json:"accent"`
+
+	Semantic struct {
+		Border    string `json:"border"`
+		BorderDim string `json:"border_dim"`
+		Focus     string `json:"focus"`
+		Error     string `json:"error"`
+		Warning   string `json:"warning"`
+		Success   string `json:"success"`
+		Info      string `json:"info"`
+	} `json:"semantic"`
+}
+
+// BuiltinThemeNames lists the themes bundled with the binary, in the order
+// a theme-cycling keybinding should offer them. "no-color" is a 16-color
+// fallback for terminals without truecolor support.
+var BuiltinThemeNames = []string{
+	"tokyonight",
+	"dracula",
+	"gruvbox",
+	"nord",
+	"solarized-dark",
+	"solarized-light",
+	"no-color",
+}
+
+// DefaultThemeName is used when NVIM_HOVERFLOAT_THEME is unset and no
+// theme was requested explicitly.
+const DefaultThemeName = "tokyonight"
+
+// LoadTheme reads a theme by name, checking the user's
+// ~/.config/nvim-hoverfloat/themes/<name>.json override before falling
+// back to the bundled theme of the same name.
+func LoadTheme(name string) (Theme, error) {
+	data, err := assets.Open(fmt.Sprintf("/styles/%s.json", name))
+	if err != nil {
+		return Theme{}, fmt.Errorf("unknown theme %q: %w", name, err)
+	}
+
+	var theme Theme
+	if err := json.Unmarshal(data, &theme); err != nil {
+		return Theme{}, fmt.Errorf("invalid theme file for %q: %w", name, err)
+	}
+	return theme, nil
+}
+
+// LoadThemeFile reads a theme from an arbitrary JSON file path, for users
+// who keep a custom theme outside the themes override directory.
+func LoadThemeFile(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, fmt.Errorf("failed to read theme file: %w", err)
+	}
+
+	var theme Theme
+	if err := json.Unmarshal(data, &theme); err != nil {
+		return Theme{}, fmt.Errorf("invalid theme file: %w", err)
+	}
+	return theme, nil
+}
+
+// ResolveThemeName picks the active theme name: the NVIM_HOVERFLOAT_THEME
+// env var if set, else "no-color" when Renderer's color profile is
+// termenv.Ascii, i.e. no color at all (e.g. a plain TERM=dumb or a piped
+// terminal), else DefaultThemeName. 16- and 256-color profiles also get
+// DefaultThemeName.
+func ResolveThemeName() string {
+	if name := os.Getenv("NVIM_HOVERFLOAT_THEME"); name != "" {
+		return name
+	}
+	if Renderer.ColorProfile() == termenv.Ascii {
+		return "no-color"
+	}
+	return DefaultThemeName
+}
@@ -1,6 +1,7 @@
 package view
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -8,29 +9,78 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/star-lance/nvim-hoverfloat/cmd/context-tui/internal/socket"
-	"github.com/star-lance/nvim-hoverfloat/cmd/context-tui/internal/styles"
+	"nvim-hoverfloat/cmd/context-tui/internal/assets"
+	"nvim-hoverfloat/cmd/context-tui/internal/lsp"
+	"nvim-hoverfloat/cmd/context-tui/internal/socket"
+	"nvim-hoverfloat/cmd/context-tui/internal/styles"
 )
 
 // ViewData contains all the data needed to render the view
 type ViewData struct {
-	Context        *socket.ContextData
-	ErrorMsg       string
-	Connected      bool
-	LastUpdate     time.Time
-	Focus          int
-	ShowHover      bool
-	ShowReferences bool
-	ShowDefinition bool
-	ShowTypeInfo   bool
-	MenuVisible    bool
-	MenuSelection  int
+	Context    *socket.ContextData
+	ErrorMsg   string
+	Connected  bool
+	LastUpdate time.Time
+
+	// Reconnecting is set while the active session's connection has
+	// dropped but may still redial within its reconnectWindow.
+	// ReconnectAttempt/ReconnectNextAttempt mirror the most recent
+	// ReconnectStatusMsg so the header can show a live retry count and
+	// ETA instead of a static "Reconnecting" label.
+	Reconnecting         bool
+	ReconnectAttempt     int
+	ReconnectNextAttempt time.Time
+
+	Focus               int
+	ShowHover           bool
+	ShowReferences      bool
+	ShowDefinition      bool
+	ShowTypeInfo        bool
+	ShowCompletionItems bool
+	ShowDiagnostics     bool
+	MenuVisible         bool
+	MenuSelection       int
+	Horizontal          bool // true when the pane manager is split side-by-side
+
+	// Sessions lists the currently attached Neovim instances for the
+	// header's tab strip. It's nil/empty for the common 1:1 case -- see
+	// renderSessionTabs.
+	Sessions []SessionTabView
+
+	// TrustViolation is set when a connecting client's key fingerprint
+	// doesn't match the one recorded on first use (see internal/auth),
+	// prompting the user to accept or reject it.
+	TrustViolation   bool
+	TrustFingerprint string
+
+	// Autocompletion popup, fed by the TUI's own LSP client rather than
+	// the Neovim plugin.
+	CompletionVisible  bool
+	CompletionPrefix   string
+	CompletionSelected int
+	CompletionItems    []CompletionItemView
 
 	// Viewport fields for scrolling
-	HoverViewport      interface{} // *viewport.Model
-	ReferencesViewport interface{} // *viewport.Model
-	DefinitionViewport interface{} // *viewport.Model
-	TypeInfoViewport   interface{} // *viewport.Model
+	HoverViewport           interface{} // *viewport.Model
+	ReferencesViewport      interface{} // *viewport.Model
+	DefinitionViewport      interface{} // *viewport.Model
+	TypeInfoViewport        interface{} // *viewport.Model
+	CompletionItemsViewport interface{} // *viewport.Model
+	DiagnosticsViewport     interface{} // *viewport.Model
+}
+
+// CompletionItemView is the subset of an lsp.CompletionItem the popup
+// needs to render; kept separate so this package doesn't depend on lsp.
+type CompletionItemView struct {
+	Label  string
+	Detail string
+}
+
+// SessionTabView is one entry in the header's session tab strip, rendered
+// as "[nvim-A | nvim-B*]" with the active session starred.
+type SessionTabView struct {
+	Name   string
+	Active bool
 }
 
 // FocusArea constants
@@ -39,6 +89,8 @@ const (
 	FocusReferences
 	FocusDefinition
 	FocusTypeDefinition
+	FocusCompletionItems
+	FocusDiagnostics
 )
 
 // Render creates the complete UI view
@@ -61,18 +113,68 @@ func Render(width, height int, data *ViewData, s *styles.Styles) string {
 		footer,
 	)
 
+	if data.CompletionVisible {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, renderCompletionPopup(data, s))
+	}
+
+	if data.TrustViolation {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, renderTrustViolationBanner(data, s))
+	}
+
 	return view
 }
 
+// renderTrustViolationBanner surfaces a client key fingerprint that
+// doesn't match the one trusted on first use, so the user can explicitly
+// accept or reject it instead of the socket silently deciding on its own.
+func renderTrustViolationBanner(data *ViewData, s *styles.Styles) string {
+	lines := []string{
+		s.StatusError.Render("⚠ Trust violation: client key fingerprint changed"),
+		s.Comment.Render(data.TrustFingerprint),
+		s.Body.Render("Accept new key? (y/n)"),
+	}
+	return s.Menu.Render(strings.Join(lines, "\n"))
+}
+
+// renderCompletionPopup renders the autocompletion item list beneath the
+// main view, highlighting the currently selected item.
+func renderCompletionPopup(data *ViewData, s *styles.Styles) string {
+	lines := []string{s.Subtitle.Render("Completion: " + data.CompletionPrefix)}
+
+	if len(data.CompletionItems) == 0 {
+		lines = append(lines, s.Comment.Render("  no matches"))
+	}
+
+	for i, item := range data.CompletionItems {
+		line := item.Label
+		if item.Detail != "" {
+			line += "  " + item.Detail
+		}
+		if i == data.CompletionSelected {
+			lines = append(lines, s.MenuItemActive.Render(line))
+		} else {
+			lines = append(lines, s.MenuItem.Render(line))
+		}
+	}
+
+	return s.Menu.Render(strings.Join(lines, "\n"))
+}
+
 // renderHeader creates the header section
 func renderHeader(width int, data *ViewData, s *styles.Styles) string {
 	// Header line with status
 	title := "hoverfloat"
+	if tabs := renderSessionTabs(data.Sessions, s); tabs != "" {
+		title = title + "  " + tabs
+	}
 
 	var status string
-	if data.Connected {
+	switch {
+	case data.Connected:
 		status = s.StatusGood.Render("● Connected")
-	} else {
+	case data.Reconnecting:
+		status = s.StatusInfo.Render("● " + reconnectStatusText(data))
+	default:
 		status = s.StatusError.Render("● Disconnected")
 	}
 
@@ -121,6 +223,40 @@ func renderHeader(width int, data *ViewData, s *styles.Styles) string {
 	return header
 }
 
+// reconnectStatusText renders the Reconnecting status label, e.g.
+// "Reconnecting (attempt 2, retry in 4s)", falling back to a bare label
+// before the first ReconnectStatusMsg tick has arrived.
+func reconnectStatusText(data *ViewData) string {
+	if data.ReconnectAttempt == 0 {
+		return "Reconnecting"
+	}
+
+	eta := time.Until(data.ReconnectNextAttempt).Round(time.Second)
+	if eta < 0 {
+		eta = 0
+	}
+	return fmt.Sprintf("Reconnecting (attempt %d, retry in %s)", data.ReconnectAttempt, eta)
+}
+
+// renderSessionTabs renders the multi-session strip shown in the header
+// once more than one Neovim instance is attached, e.g. "[nvim-A | nvim-B*]".
+// It returns "" when there's nothing to disambiguate.
+func renderSessionTabs(sessions []SessionTabView, s *styles.Styles) string {
+	if len(sessions) < 2 {
+		return ""
+	}
+
+	names := make([]string, len(sessions))
+	for i, tab := range sessions {
+		name := tab.Name
+		if tab.Active {
+			name += "*"
+		}
+		names[i] = name
+	}
+	return s.Comment.Render("[" + strings.Join(names, " | ") + "]")
+}
+
 // renderContent creates the main content area with viewports
 func renderContent(width, height int, data *ViewData, s *styles.Styles) string {
 	if data.Context == nil {
@@ -156,12 +292,28 @@ func renderContent(width, height int, data *ViewData, s *styles.Styles) string {
 	if data.ShowTypeInfo && data.Context.HasTypeDefinition() && remainingHeight > 4 {
 		section := renderTypeDefinitionSectionWithViewport(width, remainingHeight, data, s)
 		sections = append(sections, section)
+		remainingHeight -= countLines(section) + 1
+	}
+
+	if data.ShowCompletionItems && data.Context.HasCompletionItems() && remainingHeight > 4 {
+		section := renderCompletionItemsSectionWithViewport(width, remainingHeight, data, s)
+		sections = append(sections, section)
+		remainingHeight -= countLines(section) + 1
+	}
+
+	if data.ShowDiagnostics && data.Context.HasDiagnostics() && remainingHeight > 4 {
+		section := renderDiagnosticsSectionWithViewport(width, remainingHeight, data, s)
+		sections = append(sections, section)
 	}
 
 	if len(sections) == 0 {
 		return renderNoDataMessage(width, height, s)
 	}
 
+	if data.Horizontal {
+		return lipgloss.JoinHorizontal(lipgloss.Top, sections...)
+	}
+
 	// Join sections
 	content := strings.Join(sections, "\n")
 	return truncateContent(content, height)
@@ -182,7 +334,7 @@ func renderHoverSectionWithViewport(width, height int, data *ViewData, s *styles
 	// Header
 	headerText := "📖 Documentation"
 	if vp, ok := data.HoverViewport.(*viewport.Model); ok && vp != nil {
-		scrollInfo := fmt.Sprintf(" [%d%%]", vp.ScrollPercent())
+		scrollInfo := fmt.Sprintf(" [%.0f%%]", vp.ScrollPercent()*100)
 		headerText += s.Comment.Render(scrollInfo)
 	}
 	headerPadded := headerText + strings.Repeat(" ", max(0, width-lipgloss.Width(headerText)-4))
@@ -224,7 +376,7 @@ func renderReferencesSectionWithViewport(width, height int, data *ViewData, s *s
 	}
 	headerText := fmt.Sprintf("🔗 References (%d %s)", refCount, refText)
 	if vp, ok := data.ReferencesViewport.(*viewport.Model); ok && vp != nil {
-		scrollInfo := fmt.Sprintf(" [%d%%]", vp.ScrollPercent())
+		scrollInfo := fmt.Sprintf(" [%.0f%%]", vp.ScrollPercent()*100)
 		headerText += s.Comment.Render(scrollInfo)
 	}
 	headerPadded := headerText + strings.Repeat(" ", max(0, width-lipgloss.Width(headerText)-4))
@@ -315,16 +467,86 @@ func renderTypeDefinitionSectionWithViewport(width, height int, data *ViewData,
 	return s.WithWidth(sectionStyle, width).Render(sectionContent)
 }
 
+func renderCompletionItemsSectionWithViewport(width, height int, data *ViewData, s *styles.Styles) string {
+	if data.Context == nil || !data.Context.HasCompletionItems() {
+		return ""
+	}
+
+	focused := data.Focus == FocusCompletionItems
+	sectionStyle := s.Section
+	if focused {
+		sectionStyle = s.SectionFocused
+	}
+
+	// Header
+	headerText := "💡 Completion Items"
+	if vp, ok := data.CompletionItemsViewport.(*viewport.Model); ok && vp != nil {
+		scrollInfo := fmt.Sprintf(" [%.0f%%]", vp.ScrollPercent()*100)
+		headerText += s.Comment.Render(scrollInfo)
+	}
+	headerPadded := headerText + strings.Repeat(" ", max(0, width-lipgloss.Width(headerText)-4))
+	header := s.WithWidth(s.SectionHeader, width).Render(headerPadded)
+
+	// Content from viewport or fallback
+	var contentFormatted string
+	if vp, ok := data.CompletionItemsViewport.(*viewport.Model); ok && vp != nil {
+		contentFormatted = vp.View()
+	} else {
+		content := formatCompletionItems(data.Context.CompletionItems, width-4, s)
+		maxLines := min(height-3, 10)
+		contentFormatted = truncateToLines(content, maxLines)
+	}
+
+	// Join and render section
+	sectionContent := lipgloss.JoinVertical(lipgloss.Left, header, contentFormatted)
+	return s.WithWidth(sectionStyle, width).Render(sectionContent)
+}
+
+func renderDiagnosticsSectionWithViewport(width, height int, data *ViewData, s *styles.Styles) string {
+	if data.Context == nil || !data.Context.HasDiagnostics() {
+		return ""
+	}
+
+	focused := data.Focus == FocusDiagnostics
+	sectionStyle := s.Section
+	if focused {
+		sectionStyle = s.SectionFocused
+	}
+
+	// Header
+	headerText := fmt.Sprintf("⚠ Diagnostics (%d)", len(data.Context.Diagnostics))
+	if vp, ok := data.DiagnosticsViewport.(*viewport.Model); ok && vp != nil {
+		scrollInfo := fmt.Sprintf(" [%.0f%%]", vp.ScrollPercent()*100)
+		headerText += s.Comment.Render(scrollInfo)
+	}
+	headerPadded := headerText + strings.Repeat(" ", max(0, width-lipgloss.Width(headerText)-4))
+	header := s.WithWidth(s.SectionHeader, width).Render(headerPadded)
+
+	// Content from viewport or fallback
+	var contentFormatted string
+	if vp, ok := data.DiagnosticsViewport.(*viewport.Model); ok && vp != nil {
+		contentFormatted = vp.View()
+	} else {
+		content := formatDiagnostics(data.Context.Diagnostics, width-4, s)
+		maxLines := min(height-3, 10)
+		contentFormatted = truncateToLines(content, maxLines)
+	}
+
+	// Join and render section
+	sectionContent := lipgloss.JoinVertical(lipgloss.Left, header, contentFormatted)
+	return s.WithWidth(sectionStyle, width).Render(sectionContent)
+}
+
 // Helper functions
-func formatHoverContent(hover []string, width int, s *styles.Styles) string {
+func formatHoverContent(hover socket.HoverContent, width int, s *styles.Styles) string {
 	if len(hover) == 0 {
 		return s.Comment.Render("No documentation available")
 	}
 
 	// Check if content appears to be markdown
-	if isMarkdownContent(hover) {
+	if isMarkdownContent(hover.Lines()) {
 		// Join all lines and render as markdown
-		content := strings.Join(hover, "\n")
+		content := strings.Join(hover.Lines(), "\n")
 		rendered, err := renderMarkdown(content, width-4, true)
 		if err == nil && rendered != content {
 			return rendered
@@ -333,21 +555,32 @@ func formatHoverContent(hover []string, width int, s *styles.Styles) string {
 
 	// Simple text rendering with basic syntax highlighting
 	var lines []string
-	for _, line := range hover {
+	for _, span := range hover {
+		line := span.Text
+
+		var rendered string
 		// Simple syntax highlighting for code blocks
 		if strings.HasPrefix(line, "```") {
 			if strings.Contains(line, "```") && len(line) > 3 {
-				lines = append(lines, s.Code.Render(line))
+				rendered = s.Code.Render(line)
 			} else {
-				lines = append(lines, s.Comment.Render(line))
+				rendered = s.Comment.Render(line)
 			}
 		} else if strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") {
-			lines = append(lines, s.Code.Render(line))
+			rendered = s.Code.Render(line)
 		} else if strings.HasPrefix(line, "#") {
-			lines = append(lines, s.Highlight.Render(line))
+			rendered = s.Highlight.Render(line)
 		} else {
-			lines = append(lines, s.Body.Render(truncateString(line, width)))
+			rendered = s.Body.Render(truncateString(line, width))
+		}
+
+		// Actionable spans (OnClick/OnHover) get a trailing hint so the
+		// user knows there's something to trigger on this line.
+		if span.IsActionable() {
+			rendered += s.Comment.Render(" ⏎")
 		}
+
+		lines = append(lines, rendered)
 	}
 
 	return strings.Join(lines, "\n")
@@ -380,6 +613,39 @@ func formatReferences(context *socket.ContextData, width int, s *styles.Styles)
 	return strings.Join(lines, "\n")
 }
 
+func formatCompletionItems(items []lsp.CompletionItem, width int, s *styles.Styles) string {
+	if len(items) == 0 {
+		return s.Comment.Render("No completion items available")
+	}
+
+	var lines []string
+	for _, item := range items {
+		label := truncateString(item.Label, width)
+		if item.Detail != "" {
+			lines = append(lines, fmt.Sprintf("%s  %s", s.Body.Render(label), s.Comment.Render(truncateString(item.Detail, width))))
+		} else {
+			lines = append(lines, s.Body.Render(label))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func formatDiagnostics(diags []lsp.Diagnostic, width int, s *styles.Styles) string {
+	if len(diags) == 0 {
+		return s.Comment.Render("No diagnostics")
+	}
+
+	var lines []string
+	for _, diag := range diags {
+		location := fmt.Sprintf("%d:%d", diag.Range.Start.Line+1, diag.Range.Start.Character+1)
+		line := fmt.Sprintf("%s %s", location, truncateString(diag.Message, width))
+		lines = append(lines, s.PriorityColor(diag.Severity).Render(line))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 func renderWaitingMessage(width, height int, data *ViewData, s *styles.Styles) string {
 	message := "Waiting for cursor movement in Neovim..."
 	if !data.Connected {
@@ -426,6 +692,8 @@ func renderFooter(width int, data *ViewData, s *styles.Styles) string {
 		s.Keybind.Render("g/G") + " top/bot",
 		s.Keybind.Render("^u/^d") + " page",
 		s.Keybind.Render("+/-") + " resize",
+		s.Keybind.Render("|") + " split",
+		s.Keybind.Render("t") + " theme",
 		s.Keybind.Render("v") + " select",
 		s.Keybind.Render("?") + " help",
 		s.Keybind.Render("q") + " quit",
@@ -443,6 +711,10 @@ func renderFooter(width int, data *ViewData, s *styles.Styles) string {
 		modeIndicator = s.StatusInfo.Render("[Definition]")
 	} else if data.Focus == FocusTypeDefinition {
 		modeIndicator = s.StatusInfo.Render("[Type]")
+	} else if data.Focus == FocusCompletionItems {
+		modeIndicator = s.StatusInfo.Render("[Completion]")
+	} else if data.Focus == FocusDiagnostics {
+		modeIndicator = s.StatusInfo.Render("[Diagnostics]")
 	}
 
 	// Combine help and mode
@@ -554,10 +826,33 @@ func isMarkdownContent(content []string) bool {
 	return float64(markdownIndicators)/float64(totalLines) >= 0.25
 }
 
+// markdownThemeConfig is the shape of assets/markdown/*.json.
+type markdownThemeConfig struct {
+	GlamourStyle string `json:"glamour_style"`
+	WordWrap     bool   `json:"word_wrap"`
+	Emoji        bool   `json:"emoji"`
+}
+
+// markdownThemeName resolves the glamour style name to render hover
+// markdown with, reading from the embedded assets (or a user override
+// under ~/.config/nvim-hoverfloat/themes/dark.json) rather than a
+// hardcoded literal.
+func markdownThemeName() string {
+	data, err := assets.Open("/markdown/dark.json")
+	if err != nil {
+		return "dark"
+	}
+
+	var cfg markdownThemeConfig
+	if err := json.Unmarshal(data, &cfg); err != nil || cfg.GlamourStyle == "" {
+		return "dark"
+	}
+	return cfg.GlamourStyle
+}
+
 // renderMarkdown uses glamour to render markdown content
 func renderMarkdown(content string, width int, darkTheme bool) (string, error) {
-	// Use dark theme for consistency
-	style := "dark"
+	style := markdownThemeName()
 
 	var options []glamour.TermRendererOption
 	options = append(options, glamour.WithStandardStyle(style))
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -10,15 +11,64 @@ import (
 )
 
 func main() {
-	// Get socket path from command line or use default
+	var (
+		recordPath     string
+		replayPath     string
+		speed          float64
+		replayLoop     bool
+		themeName      string
+		aestheticsPath string
+		transportName  string
+		sinkSpecs      string
+	)
+	flag.StringVar(&recordPath, "record", "", "record every inbound socket message to this file (created or truncated) as length-prefixed JSON frames for later replay")
+	flag.StringVar(&replayPath, "replay", "", "replay a previously recorded session from this file instead of listening on the socket")
+	flag.Float64Var(&speed, "speed", 1.0, "replay speed multiplier (used with --replay)")
+	flag.BoolVar(&replayLoop, "replay-loop", false, "loop the replay file forever (used with --replay)")
+	flag.StringVar(&themeName, "theme", "", "color theme to use, overriding NVIM_HOVERFLOAT_THEME (tokyonight, dracula, gruvbox, nord, solarized-dark, solarized-light, no-color)")
+	flag.StringVar(&aestheticsPath, "aesthetics", "", "path to an aesthetics.conf styleset to layer on top of --theme, overriding discovery under $XDG_CONFIG_HOME/nvim-hoverfloat (use \"theme:<name>\" to load a bundled styleset, e.g. theme:gruvbox-dark)")
+	flag.StringVar(&transportName, "transport", "unix", "wire transport for accepted connections: unix (default) or shm (shared-memory ring buffer, Linux only)")
+	flag.StringVar(&sinkSpecs, "sink", "", "comma-separated list of context trace sinks, e.g. jsonl:/tmp/ctx.jsonl,console (kinds: console, jsonl:<path>, rotating:<path>[?max_size_mb=&max_backups=&max_age_days=])")
+	flag.Parse()
+
+	// Get socket path from the first positional argument or use default
 	socketPath := "/tmp/nvim_context.sock"
-	if len(os.Args) > 1 {
-		socketPath = os.Args[1]
+	if args := flag.Args(); len(args) > 0 {
+		socketPath = args[0]
 	}
 
 	// Create the initial model
 	initialModel := model.NewApp(socketPath)
 
+	if err := initialModel.SetTransport(transportName); err != nil {
+		log.Fatalf("Error setting transport: %v", err)
+	}
+	if themeName != "" {
+		if err := initialModel.SetTheme(themeName); err != nil {
+			log.Fatalf("Error setting theme: %v", err)
+		}
+	}
+	if aestheticsPath != "" {
+		if err := initialModel.SetAesthetics(aestheticsPath); err != nil {
+			log.Fatalf("Error loading aesthetics: %v", err)
+		}
+	}
+	if recordPath != "" {
+		if err := initialModel.EnableRecording(recordPath); err != nil {
+			log.Fatalf("Error enabling recording: %v", err)
+		}
+	}
+	if replayPath != "" {
+		if err := initialModel.EnableReplay(replayPath, speed, replayLoop); err != nil {
+			log.Fatalf("Error enabling replay: %v", err)
+		}
+	}
+	if sinkSpecs != "" {
+		if err := initialModel.SetSinks(sinkSpecs); err != nil {
+			log.Fatalf("Error configuring sinks: %v", err)
+		}
+	}
+
 	// Create Bubble Tea program with optimized settings
 	p := tea.NewProgram(
 		initialModel,
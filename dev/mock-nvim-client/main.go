@@ -1,40 +1,99 @@
 package main
 
 import (
+	"bufio"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
 )
 
 // ContextData represents the structure sent by the Neovim plugin
 type ContextData struct {
-	File            string         `json:"file"`
-	Line            int            `json:"line"`
-	Col             int            `json:"col"`
-	Timestamp       int64          `json:"timestamp"`
-	Hover           []string       `json:"hover,omitempty"`
-	Definition      *LocationInfo  `json:"definition,omitempty"`
-	ReferencesCount int            `json:"references_count,omitempty"`
-	References      []LocationInfo `json:"references,omitempty"`
-	ReferencesMore  int            `json:"references_more,omitempty"`
-	TypeDefinition  *LocationInfo  `json:"type_definition,omitempty"`
+	File            string           `json:"file" msgpack:"file"`
+	Line            int              `json:"line" msgpack:"line"`
+	Col             int              `json:"col" msgpack:"col"`
+	Timestamp       int64            `json:"timestamp" msgpack:"timestamp"`
+	Hover           []string         `json:"hover,omitempty" msgpack:"hover,omitempty"`
+	Definition      *LocationInfo    `json:"definition,omitempty" msgpack:"definition,omitempty"`
+	ReferencesCount int              `json:"references_count,omitempty" msgpack:"references_count,omitempty"`
+	References      []LocationInfo   `json:"references,omitempty" msgpack:"references,omitempty"`
+	ReferencesMore  int              `json:"references_more,omitempty" msgpack:"references_more,omitempty"`
+	TypeDefinition  *LocationInfo    `json:"type_definition,omitempty" msgpack:"type_definition,omitempty"`
+	CompletionItems []CompletionItem `json:"completion_items,omitempty" msgpack:"completion_items,omitempty"`
+	Diagnostics     []Diagnostic     `json:"diagnostics,omitempty" msgpack:"diagnostics,omitempty"`
 }
 
 type LocationInfo struct {
-	File string `json:"file"`
-	Line int    `json:"line"`
-	Col  int    `json:"col"`
+	File string `json:"file" msgpack:"file"`
+	Line int    `json:"line" msgpack:"line"`
+	Col  int    `json:"col" msgpack:"col"`
+}
+
+// CompletionItem mirrors the fields context-tui renders in the completion
+// items section (see internal/lsp.CompletionItem).
+type CompletionItem struct {
+	Label  string `json:"label" msgpack:"label"`
+	Detail string `json:"detail,omitempty" msgpack:"detail,omitempty"`
+}
+
+// Diagnostic mirrors the fields context-tui renders in the diagnostics
+// section (see internal/lsp.Diagnostic).
+type Diagnostic struct {
+	Range    DiagnosticRange `json:"range" msgpack:"range"`
+	Severity int             `json:"severity,omitempty" msgpack:"severity,omitempty"`
+	Message  string          `json:"message" msgpack:"message"`
+}
+
+type DiagnosticRange struct {
+	Start DiagnosticPosition `json:"start" msgpack:"start"`
+	End   DiagnosticPosition `json:"end" msgpack:"end"`
+}
+
+type DiagnosticPosition struct {
+	Line      int `json:"line" msgpack:"line"`
+	Character int `json:"character" msgpack:"character"`
 }
 
 type Message struct {
-	Type      string      `json:"type"`
-	Timestamp int64       `json:"timestamp"`
-	Data      ContextData `json:"data"`
+	Type      string      `json:"type" msgpack:"type"`
+	Timestamp int64       `json:"timestamp" msgpack:"timestamp"`
+	Data      ContextData `json:"data" msgpack:"data"`
 }
 
+// RecordedFrame is one message captured by record mode, timestamped
+// relative to the start of the recording rather than wall-clock time so
+// replay's pacing is independent of when it's played back. Recordings are
+// newline-delimited JSON, one frame per line, so they're easy to inspect
+// or edit by hand between record and replay.
+type RecordedFrame struct {
+	OffsetMS int64   `json:"offset_ms"`
+	Message  Message `json:"message"`
+}
+
+// Hello is the handshake frame that negotiates the wire proto for the
+// messages that follow, mirroring context-tui's socket/frame package.
+type Hello struct {
+	Type    string `json:"type"`
+	Proto   string `json:"proto"`
+	Version int    `json:"version"`
+}
+
+const protoVersion = 1
+
+// activeProto is the wire proto selected via --proto, consulted by
+// sendMessage. Defaults to "json", which keeps the original unframed
+// newline-delimited behavior so existing benchmarks and tooling don't
+// change unless they opt in to "msgpack".
+var activeProto = "json"
+
 type TestScenario struct {
 	Name        string      `json:"name"`
 	Description string      `json:"description"`
@@ -48,19 +107,72 @@ type Config struct {
 }
 
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: mock-nvim-client <mode>")
+	args := make([]string, 0, len(os.Args)-1)
+	speed := 1.0
+	loop := false
+	for _, arg := range os.Args[1:] {
+		if proto, ok := strings.CutPrefix(arg, "--proto="); ok {
+			activeProto = proto
+			continue
+		}
+		if speedStr, ok := strings.CutPrefix(arg, "--speed="); ok {
+			parsed, err := strconv.ParseFloat(speedStr, 64)
+			if err != nil {
+				log.Fatalf("❌ Invalid --speed value: %s", speedStr)
+			}
+			speed = parsed
+			continue
+		}
+		if arg == "--loop" {
+			loop = true
+			continue
+		}
+		args = append(args, arg)
+	}
+	if activeProto != "json" && activeProto != "msgpack" {
+		log.Fatalf("❌ Unknown --proto value: %s (want json or msgpack)", activeProto)
+	}
+
+	if len(args) < 1 {
+		fmt.Println("Usage: mock-nvim-client <mode> [--proto=json|msgpack]")
 		fmt.Println("Modes:")
-		fmt.Println("  interactive  - Send test data with menu for scenario selection")
-		fmt.Println("  scenario     - Run specific scenario from scenarios.json")
-		fmt.Println("  continuous   - Cycle through all scenarios continuously")
-		fmt.Println("  single       - Send one test message and exit")
+		fmt.Println("  interactive        - Send test data with menu for scenario selection")
+		fmt.Println("  scenario           - Run specific scenario from scenarios.json")
+		fmt.Println("  continuous         - Cycle through all scenarios continuously")
+		fmt.Println("  single             - Send one test message and exit")
+		fmt.Println("  record <file>      - Listen on the socket and capture incoming messages to <file>")
+		fmt.Println("  replay <file>      - Re-emit a recorded session, timed by its original offsets [--speed=1.0] [--loop]")
+		fmt.Println("  convert <file>     - Promote a recording into TestScenarios appended to scenarios.json")
 		os.Exit(1)
 	}
 
-	mode := os.Args[1]
+	mode := args[0]
 	socketPath := "/tmp/nvim_context.sock"
 
+	switch mode {
+	case "record":
+		if len(args) < 2 {
+			fmt.Println("Usage: mock-nvim-client record <file>")
+			os.Exit(1)
+		}
+		runRecordMode(socketPath, args[1])
+		return
+	case "replay":
+		if len(args) < 2 {
+			fmt.Println("Usage: mock-nvim-client replay <file> [--speed=1.0] [--loop]")
+			os.Exit(1)
+		}
+		runReplayMode(socketPath, args[1], speed, loop)
+		return
+	case "convert":
+		if len(args) < 2 {
+			fmt.Println("Usage: mock-nvim-client convert <file>")
+			os.Exit(1)
+		}
+		runConvertMode(args[1], "scenarios.json")
+		return
+	}
+
 	// Remove existing socket
 	os.Remove(socketPath)
 
@@ -76,12 +188,12 @@ func main() {
 	case "interactive":
 		runInteractiveMode(config)
 	case "scenario":
-		if len(os.Args) < 3 {
+		if len(args) < 2 {
 			fmt.Println("Usage: mock-nvim-client scenario <scenario_name>")
 			listScenarios(config)
 			os.Exit(1)
 		}
-		runScenario(config, os.Args[2])
+		runScenario(config, args[1])
 	case "continuous":
 		runContinuousMode(config)
 	case "single":
@@ -280,27 +392,111 @@ func getDefaultConfig() *Config {
 					ReferencesMore: 148,
 				},
 			},
+			{
+				Name:        "with_completions",
+				Description: "Cursor in a call expression with completion items available",
+				Delay:       400,
+				Data: ContextData{
+					File:      "src/main.rs",
+					Line:      30,
+					Col:       10,
+					Timestamp: time.Now().UnixMilli(),
+					CompletionItems: []CompletionItem{
+						{Label: "calculate_distance", Detail: "fn(Point, Point) -> f64"},
+						{Label: "calculate_area", Detail: "fn(Shape) -> f64"},
+						{Label: "calculate_volume", Detail: "fn(Solid) -> f64"},
+					},
+				},
+			},
+			{
+				Name:        "with_diagnostics",
+				Description: "Buffer with a mix of error, warning and hint diagnostics",
+				Delay:       400,
+				Data: ContextData{
+					File:      "src/main.rs",
+					Line:      42,
+					Col:       15,
+					Timestamp: time.Now().UnixMilli(),
+					Diagnostics: []Diagnostic{
+						{
+							Range:    DiagnosticRange{Start: DiagnosticPosition{Line: 41, Character: 4}, End: DiagnosticPosition{Line: 41, Character: 20}},
+							Severity: 1,
+							Message:  "cannot borrow `p1` as mutable, as it is not declared as mutable",
+						},
+						{
+							Range:    DiagnosticRange{Start: DiagnosticPosition{Line: 45, Character: 0}, End: DiagnosticPosition{Line: 45, Character: 10}},
+							Severity: 2,
+							Message:  "unused variable: `result`",
+						},
+						{
+							Range:    DiagnosticRange{Start: DiagnosticPosition{Line: 50, Character: 4}, End: DiagnosticPosition{Line: 50, Character: 12}},
+							Severity: 4,
+							Message:  "consider using `&str` instead of `&String`",
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
 func sendMessage(socketPath string, data ContextData) error {
+	return send(socketPath, Message{
+		Type:      "context_update",
+		Timestamp: time.Now().UnixMilli(),
+		Data:      data,
+	})
+}
+
+// send dials socketPath and writes message using the active wire proto.
+func send(socketPath string, message Message) error {
 	conn, err := net.Dial("unix", socketPath)
 	if err != nil {
 		return fmt.Errorf("failed to connect to socket: %w", err)
 	}
 	defer conn.Close()
 
-	message := Message{
-		Type:      "context_update",
-		Timestamp: time.Now().UnixMilli(),
-		Data:      data,
+	if activeProto == "msgpack" {
+		return sendFramed(conn, message)
 	}
 
 	encoder := json.NewEncoder(conn)
 	return encoder.Encode(message)
 }
 
+// sendFramed writes msg over conn using the length-prefixed msgpack wire
+// mode: a hello frame declaring the proto, followed by the message frame
+// itself, both prefixed with a 4-byte big-endian length. This mirrors
+// context-tui's socket/frame package so the two can be benchmarked
+// against the same scenarios.
+func sendFramed(conn net.Conn, msg Message) error {
+	hello, err := json.Marshal(Hello{Type: "hello", Proto: activeProto, Version: protoVersion})
+	if err != nil {
+		return fmt.Errorf("failed to marshal hello: %w", err)
+	}
+	if err := writeFrame(conn, hello); err != nil {
+		return fmt.Errorf("failed to write hello: %w", err)
+	}
+
+	data, err := msgpack.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	return writeFrame(conn, data)
+}
+
+// writeFrame writes a single 4-byte-big-endian-length-prefixed frame.
+func writeFrame(conn net.Conn, payload []byte) error {
+	var lenPrefix [4]byte
+	binary.BigEndian.PutUint32(lenPrefix[:], uint32(len(payload)))
+
+	if _, err := conn.Write(lenPrefix[:]); err != nil {
+		return err
+	}
+	_, err := conn.Write(payload)
+	return err
+}
+
 func runInteractiveMode(config *Config) {
 	fmt.Println("🔧 Mock Neovim Client - Interactive Mode")
 	fmt.Println("========================================")
@@ -403,3 +599,169 @@ func listScenarios(config *Config) {
 		fmt.Printf("  - %s: %s\n", scenario.Name, scenario.Description)
 	}
 }
+
+// runRecordMode listens on socketPath (rather than dialing it, as every
+// other mode does) and appends every incoming Message to outputPath as a
+// newline-delimited RecordedFrame, so a real Neovim editing session can be
+// captured and later replayed as a reproducible fixture.
+func runRecordMode(socketPath, outputPath string) {
+	os.Remove(socketPath)
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		log.Fatalf("❌ Failed to listen on socket: %v", err)
+	}
+	defer listener.Close()
+
+	out, err := os.Create(outputPath)
+	if err != nil {
+		log.Fatalf("❌ Failed to create recording file: %v", err)
+	}
+	defer out.Close()
+
+	fmt.Printf("🎙️  Recording to %s (waiting for a connection on %s)...\n", outputPath, socketPath)
+
+	conn, err := listener.Accept()
+	if err != nil {
+		log.Fatalf("❌ Failed to accept connection: %v", err)
+	}
+	defer conn.Close()
+
+	encoder := json.NewEncoder(out)
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var start time.Time
+	count := 0
+	for scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			log.Printf("⚠️  Skipping unparseable message: %v", err)
+			continue
+		}
+
+		if start.IsZero() {
+			start = time.Now()
+		}
+
+		frame := RecordedFrame{
+			OffsetMS: time.Since(start).Milliseconds(),
+			Message:  msg,
+		}
+		if err := encoder.Encode(frame); err != nil {
+			log.Printf("⚠️  Failed to write frame: %v", err)
+			continue
+		}
+
+		count++
+		fmt.Printf("📥 Captured %s (#%d)\n", msg.Type, count)
+	}
+
+	fmt.Printf("✅ Recording complete: %d messages written to %s\n", count, outputPath)
+}
+
+// loadRecording reads a newline-delimited RecordedFrame file produced by
+// record mode.
+func loadRecording(path string) ([]RecordedFrame, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer f.Close()
+
+	var frames []RecordedFrame
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var frame RecordedFrame
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal frame: %w", err)
+		}
+		frames = append(frames, frame)
+	}
+	return frames, scanner.Err()
+}
+
+// runReplayMode re-dials socketPath and re-emits a recorded session's
+// messages at their original inter-message delays, scaled by speed and
+// looping forever if loop is set.
+func runReplayMode(socketPath, recordingPath string, speed float64, loop bool) {
+	frames, err := loadRecording(recordingPath)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if speed <= 0 {
+		speed = 1.0
+	}
+
+	fmt.Printf("▶️  Replaying %d messages from %s at %.2fx speed\n", len(frames), recordingPath, speed)
+
+	for {
+		var prevOffset int64
+		for _, frame := range frames {
+			delay := time.Duration(float64(frame.OffsetMS-prevOffset)/speed) * time.Millisecond
+			prevOffset = frame.OffsetMS
+			if delay > 0 {
+				time.Sleep(delay)
+			}
+
+			if err := send(socketPath, frame.Message); err != nil {
+				log.Printf("❌ Error replaying %s: %v", frame.Message.Type, err)
+				continue
+			}
+			fmt.Printf("📤 Replayed %s\n", frame.Message.Type)
+		}
+
+		if !loop {
+			break
+		}
+	}
+
+	fmt.Println("✅ Replay complete")
+}
+
+// runConvertMode promotes a recording's context_update/cursor_pos messages
+// into TestScenarios and appends them to the scenarios file at
+// scenariosPath, so a captured real-world session can be replayed via the
+// ordinary `scenario`/`continuous` modes without keeping the raw recording
+// around.
+func runConvertMode(recordingPath, scenariosPath string) {
+	frames, err := loadRecording(recordingPath)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	config, err := loadConfig(scenariosPath)
+	if err != nil {
+		config = &Config{}
+	}
+
+	var prevOffset int64
+	converted := 0
+	for i, frame := range frames {
+		if frame.Message.Type != "context_update" && frame.Message.Type != "cursor_pos" {
+			continue
+		}
+
+		delay := frame.OffsetMS - prevOffset
+		prevOffset = frame.OffsetMS
+
+		config.Scenarios = append(config.Scenarios, TestScenario{
+			Name:        fmt.Sprintf("recorded_%d", i),
+			Description: fmt.Sprintf("Converted from %s (frame %d)", recordingPath, i),
+			Data:        frame.Message.Data,
+			Delay:       int(delay),
+		})
+		converted++
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		log.Fatalf("❌ Failed to marshal scenarios: %v", err)
+	}
+	if err := os.WriteFile(scenariosPath, data, 0644); err != nil {
+		log.Fatalf("❌ Failed to write scenarios file: %v", err)
+	}
+
+	fmt.Printf("✅ Converted %d frames into scenarios, appended to %s\n", converted, scenariosPath)
+}